@@ -2,91 +2,489 @@ package main
 
 import (
 	"context"
+	"crypto/rsa"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
 
+	"github.com/signalable/qauth/internal/apikey"
+	"github.com/signalable/qauth/internal/audit"
+	"github.com/signalable/qauth/internal/caller"
+	"github.com/signalable/qauth/internal/certbind"
 	"github.com/signalable/qauth/internal/config"
+	"github.com/signalable/qauth/internal/cors"
+	grpcServer "github.com/signalable/qauth/internal/delivery/grpc/server"
 	"github.com/signalable/qauth/internal/delivery/http/handler"
 	"github.com/signalable/qauth/internal/delivery/http/middleware"
 	"github.com/signalable/qauth/internal/delivery/http/routes"
+	"github.com/signalable/qauth/internal/dpop"
+	"github.com/signalable/qauth/internal/exchange"
+	"github.com/signalable/qauth/internal/impersonation"
+	"github.com/signalable/qauth/internal/lockout"
+	"github.com/signalable/qauth/internal/logger"
+	"github.com/signalable/qauth/internal/metrics"
+	"github.com/signalable/qauth/internal/ratelimit"
+	"github.com/signalable/qauth/internal/reload"
 	redisRepository "github.com/signalable/qauth/internal/repository/redis"
+	"github.com/signalable/qauth/internal/tlsconfig"
+	"github.com/signalable/qauth/internal/tracing"
 	"github.com/signalable/qauth/internal/usecase"
+	"github.com/signalable/qauth/internal/webhook"
 	"github.com/signalable/qauth/pkg/jwt"
 )
 
 func main() {
-	// 설정 로드
-	cfg, err := config.LoadConfig()
+	// 종료 신호 수신 시 취소되는 context
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// 설정 로드 (기본값 < 설정 파일 < .env < 환경 변수 < 플래그)
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		fatal("설정을 로드할 수 없습니다", err)
+	}
+
+	// 로거 설정 (표준 log 패키지 출력도 slog로 전달됨, 레벨은 reload 시 변경 가능)
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(logger.ParseLevel(cfg.LogLevel))
+	slog.SetDefault(logger.New(os.Stdout, logLevel))
+
+	// 트레이싱 초기화
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
 	if err != nil {
-		log.Fatalf("설정을 로드할 수 없습니다: %v", err)
+		fatal("트레이싱 초기화 실패", err)
 	}
+	defer shutdownTracing(context.Background())
 
 	// Redis 연결
-	redisClient := redis.NewClient(&redis.Options{
+	redisOptions := &redis.Options{
 		Addr:     cfg.Redis.Addr,
 		Password: cfg.Redis.Password,
 		DB:       cfg.Redis.DB,
-	})
+	}
+	redisClient := redis.NewClient(redisOptions)
+	defer redisClient.Close()
+	redisClient.AddHook(tracing.RedisHook(redisOptions))
+
+	// 지표 초기화
+	appMetrics := metrics.New()
+	redisClient.AddHook(appMetrics.RedisHook())
 
 	// Redis 연결 테스트
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	pingCtx, cancelPing := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelPing()
 
-	if _, err := redisClient.Ping(ctx).Result(); err != nil {
-		log.Fatalf("Redis 연결 실패: %v", err)
+	if _, err := redisClient.Ping(pingCtx).Result(); err != nil {
+		fatal("Redis 연결 실패", err)
 	}
 
+	// 웹훅 초기화 (감사 이벤트를 구독한 엔드포인트로 전송)
+	var auditSinks []audit.Sink
+	var webhookDispatcher *webhook.Dispatcher
+	if cfg.Webhook.EndpointsFile != "" {
+		endpoints, err := webhook.LoadEndpoints(cfg.Webhook.EndpointsFile)
+		if err != nil {
+			fatal("웹훅 설정 로드 실패", err)
+		}
+		webhookDispatcher = webhook.NewDispatcher(
+			endpoints,
+			webhook.NewRedisDeadLetterStore(redisClient, cfg.Webhook.DeadLetterKey),
+			webhook.Options{
+				MaxAttempts: cfg.Webhook.MaxAttempts,
+				BackoffBase: cfg.Webhook.BackoffBase,
+				BackoffMax:  cfg.Webhook.BackoffMax,
+				Timeout:     cfg.Webhook.Timeout,
+				Workers:     cfg.Webhook.Workers,
+				QueueSize:   cfg.Webhook.QueueSize,
+			},
+		)
+		auditSinks = append(auditSinks, webhookDispatcher)
+	}
+
+	// 감사 로거 초기화
+	auditLogger, err := audit.Setup(cfg.Audit, redisClient, auditSinks...)
+	if err != nil {
+		fatal("감사 로거 초기화 실패", err)
+	}
+	defer auditLogger.Close()
+
 	// JWT 서비스 초기화
-	jwtService := jwt.NewJWTService(cfg.JWT.SecretKey)
+	jwtOptions := []jwt.Option{
+		jwt.WithIssuer(cfg.JWT.Issuer),
+		jwt.WithAudience(cfg.JWT.Audience),
+	}
+	if cfg.JWT.PrivateKeyFile != "" {
+		signingKey, keyID, err := jwt.LoadSigningKey(cfg.JWT.PrivateKeyFile, cfg.JWT.KeyID)
+		if err != nil {
+			fatal("JWT 서명 키 로드 실패", err)
+		}
+		jwtOptions = append(jwtOptions, jwt.WithSigningKey(signingKey, keyID))
+	}
+	jwtService := jwt.NewJWTService(cfg.JWT.SecretKey, jwtOptions...)
 
 	// 레포지토리 초기화
-	tokenRepo := redisRepository.NewTokenRepository(redisClient, jwtService)
+	tokenRepo := tracing.InstrumentTokenRepository(redisRepository.NewTokenRepository(redisClient, jwtService))
+
+	// DPoP 증명 검증 (jti 재사용 여부는 Redis로 인스턴스 간 공유)
+	proofVerifier := dpop.NewVerifier(redisClient, cfg.DPoP)
+
+	// 파트너 API 키 (비밀 값은 bcrypt 해시로 Redis에 보관)
+	apiKeyManager := apikey.NewManager(redisClient, auditLogger)
 
 	// 유스케이스 초기화
-	authUseCase := usecase.NewAuthUseCase(tokenRepo, jwtService)
+	authUseCase := metrics.InstrumentAuthUseCase(
+		tracing.InstrumentAuthUseCase(
+			audit.InstrumentAuthUseCase(usecase.NewAuthUseCase(tokenRepo, jwtService, proofVerifier, apiKeyManager), auditLogger),
+		),
+		appMetrics,
+	)
+
+	// 토큰 교환 (RFC 8693, subject/actor 토큰은 계측된 authUseCase로 검증)
+	exchangePolicy := exchange.NewPolicy(cfg.TokenExchange)
+	exchangeUseCase := tracing.InstrumentTokenExchangeUseCase(
+		audit.InstrumentTokenExchangeUseCase(usecase.NewTokenExchangeUseCase(authUseCase, tokenRepo, jwtService, exchangePolicy), auditLogger),
+	)
+
+	// 관리자 대리 로그인 (시작 이벤트를 감사 로그에 남기지 못하면 발급하지 않음)
+	impersonationManager := impersonation.NewManager(redisClient, tokenRepo, jwtService, authUseCase, cfg.Impersonation, auditLogger)
+
+	// 활성 세션 지표 갱신
+	go appMetrics.RunSessionGauge(ctx, tokenRepo.CountActive, 30*time.Second)
+
+	// TLS 초기화 (인증서 파일 설정 시, 클라이언트 CA까지 설정하면 내부 API에 mTLS 적용)
+	var tlsManager *tlsconfig.Manager
+	if cfg.Server.TLS.Enabled() {
+		tlsManager, err = tlsconfig.NewManager(cfg.Server.TLS)
+		if err != nil {
+			fatal("TLS 설정 실패", err)
+		}
+	}
 
 	// 핸들러 및 미들웨어 초기화
-	authHandler := handler.NewAuthHandler(authUseCase)
+	certResolver, err := certbind.NewResolver(cfg.CertBinding)
+	if err != nil {
+		fatal("인증서 바인딩 설정 실패", err)
+	}
+	authHandler := handler.NewAuthHandler(authUseCase, certResolver)
+	keysHandler := handler.NewKeysHandler(jwtService)
+	forwardAuthHandler, err := handler.NewForwardAuthHandler(authUseCase, cfg.ForwardAuth)
+	if err != nil {
+		fatal("프록시 위임 인증 설정 실패", err)
+	}
+	extAuthzServer := grpcServer.NewExtAuthzServer(authUseCase, cfg.ForwardAuth)
 	authMiddleware := middleware.NewAuthMiddleware(authUseCase)
+	healthHandler := handler.NewHealthHandler(redisClient, jwtService)
+	clientCertMiddleware := middleware.NewClientCertMiddleware(tlsManager)
+
+	// 내부 API 호출자 인증 (HTTP 메시지 서명, HMAC 서명, API 키, mTLS 인증서 순으로 확인)
+	callers, err := caller.NewRegistry(cfg.InternalAuth.CallersFile)
+	if err != nil {
+		fatal("내부 API 호출자 목록 로드 실패", err)
+	}
+	if callers.Empty() {
+		slog.Warn("내부 API 호출자 목록이 없어 localhost에서 온 내부 API 요청을 인증 없이 허용합니다 (development 전용)")
+	}
+	nonces := caller.NewRedisNonceStore(redisClient)
+	callerAuthMiddleware := middleware.NewCallerAuthMiddleware(caller.NewAuth(callers, tlsManager,
+		caller.NewMessageSignatureAuthenticator(callers, nonces, cfg.InternalAuth.SignatureTolerance, cfg.InternalAuth.SignedHeaders),
+		caller.NewHMACAuthenticator(callers, nonces, cfg.InternalAuth.SignatureTolerance),
+		caller.NewAPIKeyAuthenticator(callers),
+		caller.NewClientCertAuthenticator(callers),
+	), tlsManager)
+
+	// 로그인 실패 누적 잠금 (User Service가 /api/auth/login/* 로 시도 허용 여부 확인 및 결과 보고)
+	lockoutGuard := lockout.NewGuard(redisClient, cfg.Lockout, auditLogger)
+	lockoutHandler := handler.NewLockoutHandler(lockoutGuard)
 
 	// 라우터 설정
 	router := mux.NewRouter()
-	routes.SetupAuthRoutes(router, authHandler, authMiddleware)
-
-	// CORS 미들웨어 설정
-	router.Use(func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Access-Control-Allow-Origin", "*")
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-User-ID")
-
-			if r.Method == "OPTIONS" {
-				w.WriteHeader(http.StatusOK)
-				return
-			}
+	router.Use(tracing.Middleware)
+	router.Use(middleware.RequestID)
+	router.Use(middleware.AccessLog)
+	router.Use(middleware.AuditContext)
+	router.Use(clientCertMiddleware.Identify)
+	router.Use(certResolver.Handler)
+	router.Use(appMetrics.Middleware)
+
+	// 요청 제한 (라우트별 한도, 인스턴스 간 Redis로 공유, user/client 키 한도는 인증 미들웨어 뒤에서 적용)
+	rateLimiter := ratelimit.NewMiddleware(ratelimit.NewLimiter(redisClient, "ratelimit:"), cfg.RateLimit, routes.AnonymousPaths, appMetrics.RateLimitRejected)
+	router.Use(rateLimiter.Handler)
+	callerAuthMiddleware.Use(rateLimiter.Identified)
+	authMiddleware.Use(rateLimiter.Identified)
+
+	if cfg.Server.MetricsAddr == "" {
+		routes.SetupMetricsRoutes(router, appMetrics.Handler(), callerAuthMiddleware)
+	}
+	routes.SetupHealthRoutes(router, healthHandler)
+	routes.SetupTokenExchangeRoutes(router, handler.NewTokenExchangeHandler(exchangeUseCase), callerAuthMiddleware)
+	routes.SetupAuthRoutes(router, authHandler, authMiddleware, callerAuthMiddleware)
+	routes.SetupKeyRoutes(router, keysHandler)
+	routes.SetupForwardAuthRoutes(router, forwardAuthHandler, callerAuthMiddleware)
+	routes.SetupLockoutRoutes(router, lockoutHandler, callerAuthMiddleware)
+	routes.SetupAPIKeyRoutes(router, handler.NewAPIKeyHandler(apiKeyManager), callerAuthMiddleware)
+	routes.SetupImpersonationRoutes(router, handler.NewImpersonationHandler(impersonationManager), callerAuthMiddleware)
+	if webhookDispatcher != nil {
+		routes.SetupWebhookRoutes(router, handler.NewWebhookHandler(webhookDispatcher), callerAuthMiddleware)
+	}
 
-			next.ServeHTTP(w, r)
+	// CORS 설정 (preflight도 처리하도록 라우터 전체를 감쌈)
+	corsPolicy := cors.New(cfg.CORS, routes.InternalPaths)
+
+	// 설정 및 키 파일 hot reload (SIGHUP 또는 파일 변경 시)
+	reloader := reload.New(cfg, func() (*config.Config, error) {
+		return config.Load(os.Args[1:])
+	}, auditLogger)
+	reloader.Register("jwt_keys", []string{"jwt.secret_key", "jwt.private_key_file", "jwt.key_id"}, reloadJWTKeys(jwtService))
+	reloader.Register("log_level", []string{"log_level"}, func(cfg *config.Config) (func(), error) {
+		return func() { logLevel.Set(logger.ParseLevel(cfg.LogLevel)) }, nil
+	})
+	reloader.Register("cors", []string{
+		"cors.allowed_origins",
+		"cors.allowed_methods",
+		"cors.allowed_headers",
+		"cors.exposed_headers",
+		"cors.allow_credentials",
+		"cors.max_age",
+		"cors.routes",
+	}, func(cfg *config.Config) (func(), error) {
+		return corsPolicy.Prepare(cfg.CORS)
+	})
+	reloader.Register("rate_limit", []string{"rate_limit.enabled", "rate_limit.routes"}, func(cfg *config.Config) (func(), error) {
+		return rateLimiter.Prepare(cfg.RateLimit)
+	})
+	reloader.Register("lockout", []string{
+		"lockout.max_failures",
+		"lockout.ip_max_failures",
+		"lockout.failure_window",
+		"lockout.base_delay",
+		"lockout.max_delay",
+		"lockout.lock_duration",
+	}, func(cfg *config.Config) (func(), error) {
+		return lockoutGuard.Prepare(cfg.Lockout)
+	})
+	reloader.Register("internal_auth", []string{"internal_auth.callers_file"}, func(cfg *config.Config) (func(), error) {
+		return callers.Prepare(cfg.InternalAuth.CallersFile)
+	})
+	reloader.Register("cert_binding", []string{"cert_binding.forwarded_header", "cert_binding.trusted_proxies"}, func(cfg *config.Config) (func(), error) {
+		return certResolver.Prepare(cfg.CertBinding)
+	})
+	if webhookDispatcher != nil {
+		reloader.Register("webhook", []string{"webhook.endpoints_file"}, func(cfg *config.Config) (func(), error) {
+			return webhookDispatcher.Prepare(cfg.Webhook.EndpointsFile)
 		})
+	}
+	reloader.Register("forward_auth", []string{"forward_auth.trusted_proxies", "forward_auth.audiences"}, func(cfg *config.Config) (func(), error) {
+		applyHandler, err := forwardAuthHandler.Prepare(cfg.ForwardAuth)
+		if err != nil {
+			return nil, err
+		}
+		applyExtAuthz, err := extAuthzServer.Prepare(cfg.ForwardAuth)
+		if err != nil {
+			return nil, err
+		}
+		return func() {
+			applyHandler()
+			applyExtAuthz()
+		}, nil
+	})
+	reloader.Register("token_exchange", []string{"token_exchange.token_ttl", "token_exchange.audiences"}, func(cfg *config.Config) (func(), error) {
+		return exchangePolicy.Prepare(cfg.TokenExchange)
+	})
+	reloader.Register("impersonation", []string{"impersonation.scopes", "impersonation.default_ttl", "impersonation.max_ttl"}, func(cfg *config.Config) (func(), error) {
+		return impersonationManager.Prepare(cfg.Impersonation)
 	})
+	reloader.Register("dpop", []string{"dpop.proof_max_age", "dpop.base_url"}, func(cfg *config.Config) (func(), error) {
+		return proofVerifier.Prepare(cfg.DPoP)
+	})
+	if tlsManager != nil {
+		reloader.Register("tls", []string{
+			"server.tls.cert_file",
+			"server.tls.key_file",
+			"server.tls.client_ca_file",
+			"server.tls.client_identities",
+		}, func(cfg *config.Config) (func(), error) {
+			if !cfg.Server.TLS.Enabled() {
+				return nil, errors.New("TLS를 끄려면 재시작해야 합니다")
+			}
+			return tlsManager.Prepare(cfg.Server.TLS)
+		})
+	}
+	go func() {
+		if err := reloader.Run(ctx); err != nil {
+			slog.Error("설정 파일 감시 시작 실패 (SIGHUP reload도 비활성화됨)", slog.Any("error", err))
+		}
+	}()
+
+	// 서버 실행 에러 (하나라도 실패하면 종료)
+	serveErr := make(chan error, 3)
+
+	// gRPC 서버 시작 (HTTP와 별도 포트)
+	var authGRPCServer *grpc.Server
+	var grpcHealth *health.Server
+	if cfg.Server.GRPCPort != "" {
+		grpcAddr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.GRPCPort)
+		listener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			fatal("gRPC 리스너 생성 실패", err)
+		}
+
+		grpcOptions := []grpc.ServerOption{
+			grpc.ChainUnaryInterceptor(
+				tracing.UnaryServerInterceptor,
+				appMetrics.UnaryServerInterceptor,
+				grpcServer.RequestInfoInterceptor,
+				grpcServer.ClientCertInterceptor(tlsManager),
+				grpcServer.CallerAuthInterceptor(caller.NewAuth(callers, tlsManager,
+					caller.NewAPIKeyAuthenticator(callers),
+					caller.NewClientCertAuthenticator(callers),
+				), tlsManager, grpcServer.InternalOperations, grpcServer.PublicMethods),
+			),
+		}
+		if tlsManager != nil {
+			grpcOptions = append(grpcOptions, grpc.Creds(credentials.NewTLS(tlsManager.TLSConfig("h2"))))
+		}
+
+		authGRPCServer, grpcHealth = grpcServer.NewGRPCServer(
+			grpcServer.NewAuthServer(authUseCase),
+			extAuthzServer,
+			grpcOptions...,
+		)
+		go func() {
+			slog.Info("Auth gRPC Service 시작", slog.String("addr", grpcAddr))
+			if err := authGRPCServer.Serve(listener); err != nil {
+				serveErr <- fmt.Errorf("gRPC 서버 실행 실패: %w", err)
+			}
+		}()
+	}
+
+	// 지표 전용 내부 리스너 (TLS/호출자 인증 없이 내부 네트워크의 Prometheus만 접근하는 주소에 둠)
+	var metricsServer *http.Server
+	if cfg.Server.MetricsAddr != "" {
+		metricsRouter := http.NewServeMux()
+		metricsRouter.Handle("/metrics", appMetrics.Handler())
+		metricsServer = &http.Server{
+			Addr:              cfg.Server.MetricsAddr,
+			Handler:           metricsRouter,
+			ReadHeaderTimeout: 5 * time.Second,
+		}
+		go func() {
+			slog.Info("지표 리스너 시작", slog.String("addr", cfg.Server.MetricsAddr))
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serveErr <- fmt.Errorf("지표 리스너 실행 실패: %w", err)
+			}
+		}()
+	}
 
 	// 서버 시작
 	serverAddr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
-	log.Printf("Auth Service 시작: %s", serverAddr)
-
 	server := &http.Server{
 		Addr:         serverAddr,
-		Handler:      router,
+		Handler:      corsPolicy.Handler(router),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
 
-	if err := server.ListenAndServe(); err != nil {
-		log.Fatalf("서버 실행 실패: %v", err)
+	go func() {
+		slog.Info("Auth Service 시작", slog.String("addr", serverAddr), slog.Bool("tls", tlsManager != nil), slog.Bool("mtls", tlsManager.MutualTLS()))
+
+		var err error
+		if tlsManager != nil {
+			server.TLSConfig = tlsManager.TLSConfig("h2", "http/1.1")
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("서버 실행 실패: %w", err)
+		}
+	}()
+
+	// 종료 신호 또는 서버 실행 실패 대기
+	select {
+	case <-ctx.Done():
+		slog.Info("종료 신호 수신, 요청 유입 차단 대기", slog.Duration("drain_period", cfg.Server.DrainPeriod))
+	case err := <-serveErr:
+		slog.Error("서버 실행 실패로 종료합니다", slog.Any("error", err))
+	}
+	stop()
+
+	// readiness 실패로 전환 후 로드밸런서가 트래픽을 뺄 때까지 대기
+	healthHandler.Drain()
+	if grpcHealth != nil {
+		grpcHealth.Shutdown()
+	}
+	time.Sleep(cfg.Server.DrainPeriod)
+
+	// 처리 중인 요청 완료 대기
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancelShutdown()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("HTTP 서버 종료 실패", slog.Any("error", err))
+	}
+	if authGRPCServer != nil {
+		stopGRPC(shutdownCtx, authGRPCServer)
+	}
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+			slog.Error("지표 리스너 종료 실패", slog.Any("error", err))
+		}
+	}
+
+	// 이후 defer로 감사 로거(웹훅 대기열), Redis, 트레이싱 순서로 정리됨
+	slog.Info("Auth Service 종료")
+}
+
+// stopGRPC 처리 중인 RPC 완료를 기다리되 시간이 지나면 강제 종료
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		slog.Warn("gRPC 서버 종료 시간 초과, 강제 종료합니다")
+		server.Stop()
+	}
+}
+
+// reloadJWTKeys 새 설정의 시크릿/서명 키로 JWT 키 세트 교체
+func reloadJWTKeys(jwtService *jwt.Service) reload.Func {
+	return func(cfg *config.Config) (func(), error) {
+		var signingKey *rsa.PrivateKey
+		var keyID string
+		if cfg.JWT.PrivateKeyFile != "" {
+			key, kid, err := jwt.LoadSigningKey(cfg.JWT.PrivateKeyFile, cfg.JWT.KeyID)
+			if err != nil {
+				return nil, err
+			}
+			signingKey, keyID = key, kid
+		}
+
+		return func() {
+			jwtService.RotateKeys(cfg.JWT.SecretKey, signingKey, keyID)
+		}, nil
 	}
 }
+
+// fatal 에러 로그 후 종료
+func fatal(msg string, err error) {
+	slog.Error(msg, slog.Any("error", err))
+	os.Exit(1)
+}
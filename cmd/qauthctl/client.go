@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/signalable/qauth/internal/caller"
+)
+
+// clientRegister internal_auth.callers_file에 내부 API 호출자 등록
+//
+// API 키와 HMAC 시크릿은 여기서 한 번만 출력하며, API 키는 해시만 파일에 저장합니다.
+// 실행 중인 서버는 파일 변경을 감지해 새 호출자를 반영합니다.
+func clientRegister(_ context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("client register", flag.ContinueOnError)
+	id := flags.String("id", "", "호출자 ID")
+	ops := flags.String("ops", "", "허용 작업 (쉼표 구분, \"admin.*\" 같은 접두사 패턴 허용)")
+	auth := flags.String("auth", "api-key", "자격 증명 종류 (api-key|hmac|cert)")
+	certIdentity := flags.String("cert-identity", "", "클라이언트 인증서 식별자 (-auth cert에 필요)")
+	bindCertificate := flags.Bool("bind-certificate", false, "발급 토큰을 클라이언트 인증서에 바인딩 (RFC 8705)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *id == "" {
+		return errors.New("-id 플래그가 필요합니다")
+	}
+	operations, err := parseOperations(*ops)
+	if err != nil {
+		return err
+	}
+
+	path, err := a.callersFile()
+	if err != nil {
+		return err
+	}
+	callers, err := readCallers(path)
+	if err != nil {
+		return err
+	}
+	for _, c := range callers {
+		if c.ID == *id {
+			return fmt.Errorf("이미 등록된 호출자입니다: %s", *id)
+		}
+	}
+
+	c := caller.Caller{
+		ID:                      *id,
+		Operations:              operations,
+		CertIdentity:            *certIdentity,
+		BindTokensToCertificate: *bindCertificate,
+	}
+	result := map[string]interface{}{
+		"id":         c.ID,
+		"operations": strings.Join(operations, ","),
+		"file":       path,
+	}
+	switch *auth {
+	case "api-key":
+		key, err := randomSecret()
+		if err != nil {
+			return err
+		}
+		c.APIKeySHA256 = caller.HashAPIKey(key)
+		result["api_key"] = key
+	case "hmac":
+		secret, err := randomSecret()
+		if err != nil {
+			return err
+		}
+		c.HMACSecret = secret
+		result["hmac_secret"] = secret
+	case "cert":
+		if c.CertIdentity == "" {
+			return errors.New("-auth cert에는 -cert-identity 플래그가 필요합니다")
+		}
+	default:
+		return fmt.Errorf("지원하지 않는 자격 증명 종류입니다: %s", *auth)
+	}
+	if c.BindTokensToCertificate && c.CertIdentity == "" {
+		return errors.New("-bind-certificate에는 -cert-identity 플래그가 필요합니다")
+	}
+
+	if err := writeCallers(path, append(callers, c)); err != nil {
+		return err
+	}
+	return a.out.Print(result)
+}
+
+// clientList 등록된 호출자 조회 (시크릿은 출력하지 않음)
+func clientList(_ context.Context, a *app, _ []string) error {
+	path, err := a.callersFile()
+	if err != nil {
+		return err
+	}
+	callers, err := readCallers(path)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]map[string]interface{}, 0, len(callers))
+	for _, c := range callers {
+		rows = append(rows, map[string]interface{}{
+			"id":          c.ID,
+			"operations":  strings.Join(c.Operations, ","),
+			"credentials": strings.Join(credentials(c), ","),
+		})
+	}
+	return a.out.PrintRows([]string{"id", "operations", "credentials"}, rows)
+}
+
+// clientRemove 호출자 삭제
+func clientRemove(_ context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return errors.New("삭제할 호출자 ID가 필요합니다")
+	}
+
+	path, err := a.callersFile()
+	if err != nil {
+		return err
+	}
+	callers, err := readCallers(path)
+	if err != nil {
+		return err
+	}
+
+	remaining := make([]caller.Caller, 0, len(callers))
+	for _, c := range callers {
+		if c.ID != args[0] {
+			remaining = append(remaining, c)
+		}
+	}
+	if len(remaining) == len(callers) {
+		return fmt.Errorf("등록되지 않은 호출자입니다: %s", args[0])
+	}
+
+	if err := writeCallers(path, remaining); err != nil {
+		return err
+	}
+	return a.out.Print(map[string]interface{}{"id": args[0], "removed": true})
+}
+
+// callersFile 호출자 목록 파일 경로
+func (a *app) callersFile() (string, error) {
+	if a.cfg.InternalAuth.CallersFile == "" {
+		return "", errors.New("internal_auth.callers_file이 설정되어 있지 않습니다")
+	}
+	return a.cfg.InternalAuth.CallersFile, nil
+}
+
+// readCallers 호출자 목록 로드 (파일이 없으면 빈 목록)
+func readCallers(path string) ([]caller.Caller, error) {
+	callers, err := caller.LoadCallers(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return callers, err
+}
+
+// writeCallers 호출자 목록 저장
+func writeCallers(path string, callers []caller.Caller) error {
+	if callers == nil {
+		callers = []caller.Caller{}
+	}
+	data, err := json.MarshalIndent(callers, "", "  ")
+	if err != nil {
+		return fmt.Errorf("호출자 목록 직렬화 실패: %w", err)
+	}
+	return writeFileAtomic(path, append(data, '\n'))
+}
+
+// parseOperations 허용 작업 목록 파싱 (정의된 작업과 하나도 맞지 않는 패턴은 오타로 보고 거부)
+func parseOperations(value string) ([]string, error) {
+	var operations []string
+	for _, op := range strings.Split(value, ",") {
+		op = strings.TrimSpace(op)
+		if op == "" {
+			continue
+		}
+		pattern := caller.Caller{Operations: []string{op}}
+		known := false
+		for _, k := range caller.KnownOperations {
+			if pattern.Allows(k) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("알 수 없는 작업입니다: %s (사용 가능: %s)", op, strings.Join(caller.KnownOperations, ", "))
+		}
+		operations = append(operations, op)
+	}
+	if len(operations) == 0 {
+		return nil, errors.New("-ops 플래그가 필요합니다")
+	}
+	return operations, nil
+}
+
+// credentials 호출자에 등록된 자격 증명 종류
+func credentials(c caller.Caller) []string {
+	var kinds []string
+	if c.APIKeySHA256 != "" {
+		kinds = append(kinds, "api-key")
+	}
+	if c.HMACSecret != "" {
+		kinds = append(kinds, "hmac")
+	}
+	if c.SignaturePublicKey != "" {
+		kinds = append(kinds, "ed25519")
+	}
+	if c.CertIdentity != "" {
+		kinds = append(kinds, "cert")
+	}
+	return kinds
+}
+
+// randomSecret 32바이트 무작위 값 (base64url)
+func randomSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("시크릿 생성 실패: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
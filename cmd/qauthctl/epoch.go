@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"time"
+)
+
+// epochBump 폐기 기준 시각을 현재 시각으로 갱신 (-user가 없으면 모든 사용자의 토큰 무효화)
+//
+// 세션 목록에 없는 토큰(키 유출 등)도 발급 시각(iat)으로 걸러내며, 이후 새로 발급된 토큰만 유효합니다.
+func epochBump(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("epoch bump", flag.ContinueOnError)
+	userID := fs.String("user", "", "토큰을 무효화할 사용자 ID (없으면 전체)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	epoch, err := a.tokenRepo.BumpEpoch(ctx, *userID)
+	if err != nil {
+		return err
+	}
+	return a.out.Print(map[string]interface{}{
+		"scope": epochScope(*userID),
+		"epoch": time.Unix(epoch, 0).Format(time.RFC3339),
+	})
+}
+
+// epochShow 전체 및 사용자별 폐기 기준 시각 조회
+func epochShow(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("epoch show", flag.ContinueOnError)
+	userID := fs.String("user", "", "조회할 사용자 ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	global, user, err := a.tokenRepo.Epochs(ctx, *userID)
+	if err != nil {
+		return err
+	}
+	result := map[string]interface{}{"global": formatEpoch(global)}
+	if *userID != "" {
+		result["user"] = formatEpoch(user)
+	}
+	return a.out.Print(result)
+}
+
+func epochScope(userID string) string {
+	if userID == "" {
+		return "global"
+	}
+	return "user:" + userID
+}
+
+func formatEpoch(epoch int64) string {
+	if epoch == 0 {
+		return "-"
+	}
+	return time.Unix(epoch, 0).Format(time.RFC3339)
+}
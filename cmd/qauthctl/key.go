@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/signalable/qauth/pkg/jwt"
+)
+
+// keyGenerate JWT_SECRET_KEY로 사용할 새 서명 키 생성
+func keyGenerate(_ context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("key generate", flag.ContinueOnError)
+	size := fs.Int("bytes", 32, "키 길이 (바이트)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *size < 32 {
+		return fmt.Errorf("키 길이는 최소 32바이트여야 합니다: %d", *size)
+	}
+
+	buf := make([]byte, *size)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Errorf("키 생성 실패: %w", err)
+	}
+
+	return a.out.Print(map[string]interface{}{
+		"algorithm":      "HS256",
+		"jwt_secret_key": base64.RawURLEncoding.EncodeToString(buf),
+	})
+}
+
+// keyRotate jwt.private_key_file을 새 RS256 키로 교체
+//
+// 실행 중인 서버는 키 파일 변경을 감지해 새 키로 발급하고, 직전 키는 한 세대 동안 검증과 JWKS 배포에 유지합니다.
+// kid가 키마다 달라야 검증 측이 구분할 수 있으므로 jwt.key_id를 비워 thumbprint를 kid로 쓰는 경우만 지원합니다.
+func keyRotate(_ context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("key rotate", flag.ContinueOnError)
+	bits := flags.Int("bits", 2048, "RSA 키 길이 (비트)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *bits < 2048 {
+		return fmt.Errorf("RSA 키 길이는 최소 2048비트여야 합니다: %d", *bits)
+	}
+
+	path := a.cfg.JWT.PrivateKeyFile
+	if path == "" {
+		return errors.New("jwt.private_key_file이 설정되어 있지 않습니다 (HS256 시크릿은 key generate로 생성)")
+	}
+	if a.cfg.JWT.KeyID != "" {
+		return fmt.Errorf("jwt.key_id가 %q로 고정되어 있어 교체된 키를 구분할 수 없습니다 (key_id를 비우면 키 thumbprint를 kid로 사용)", a.cfg.JWT.KeyID)
+	}
+
+	// 처음 키를 만드는 경우에는 직전 키가 없음
+	_, previousKeyID, err := jwt.LoadSigningKey(path, "")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, *bits)
+	if err != nil {
+		return fmt.Errorf("키 생성 실패: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("키 인코딩 실패: %w", err)
+	}
+	if err := writeFileAtomic(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})); err != nil {
+		return err
+	}
+
+	_, keyID, err := jwt.LoadSigningKey(path, "")
+	if err != nil {
+		return err
+	}
+	return a.out.Print(map[string]interface{}{
+		"algorithm":       "RS256",
+		"key_file":        path,
+		"key_id":          keyID,
+		"previous_key_id": previousKeyID,
+	})
+}
+
+// writeFileAtomic 같은 디렉터리의 임시 파일에 쓴 뒤 rename으로 교체 (감시 중인 서버가 쓰다 만 파일을 읽지 않도록)
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("임시 파일 생성 실패: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("파일 권한 설정 실패: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("파일 쓰기 실패: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("파일 쓰기 실패: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("파일 교체 실패: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+// qauthctl qauth 서비스 운영용 관리 CLI
+//
+// 사용법:
+//
+//	qauthctl [-o table|json] <command> <subcommand> [flags]
+//
+// 명령:
+//
+//	token mint -user <id>        디버깅용 토큰 발급
+//	token decode <token>         서명 검증 없이 토큰 디코딩
+//	token verify <token>         서명 및 Redis 세션 검증
+//	session list -user <id>      사용자 세션 조회
+//	session revoke <token>       토큰 폐기
+//	session revoke-all -user <id> 사용자의 모든 세션 폐기
+//	key generate [-bytes 32]     새 HS256 시크릿 생성
+//	key rotate [-bits 2048]      RS256 서명 키 파일 교체 (실행 중인 서버는 hot reload로 반영)
+//	epoch bump [-user <id>]      이 시각 이전에 발급된 토큰 일괄 무효화 (전체 또는 사용자)
+//	epoch show [-user <id>]      폐기 기준 시각 조회
+//	client register -id <id> -ops <ops> [-auth api-key|hmac|cert]  내부 API 호출자 등록
+//	client list                  등록된 호출자 조회
+//	client remove <id>           호출자 삭제
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/signalable/qauth/internal/config"
+	"github.com/signalable/qauth/internal/repository"
+	redisRepository "github.com/signalable/qauth/internal/repository/redis"
+	"github.com/signalable/qauth/internal/usecase"
+	"github.com/signalable/qauth/pkg/jwt"
+)
+
+// app 명령 실행에 필요한 의존성
+type app struct {
+	out         *printer
+	cfg         *config.Config
+	jwtService  *jwt.Service
+	tokenRepo   repository.TokenRepository
+	authUseCase usecase.AuthUseCase
+}
+
+// command 하위 명령 핸들러
+type command func(ctx context.Context, a *app, args []string) error
+
+var commands = map[string]map[string]command{
+	"token": {
+		"mint":   tokenMint,
+		"decode": tokenDecode,
+		"verify": tokenVerify,
+	},
+	"session": {
+		"list":       sessionList,
+		"revoke":     sessionRevoke,
+		"revoke-all": sessionRevokeAll,
+	},
+	"key": {
+		"generate": keyGenerate,
+		"rotate":   keyRotate,
+	},
+	"epoch": {
+		"bump": epochBump,
+		"show": epochShow,
+	},
+	"client": {
+		"register": clientRegister,
+		"list":     clientList,
+		"remove":   clientRemove,
+	},
+}
+
+// requirement 명령 실행 전에 준비할 의존성
+type requirement int
+
+const (
+	// needRedis 설정, Redis, 서비스 계층 (기본값)
+	needRedis requirement = iota
+	// needConfig 설정만 로드
+	needConfig
+	// needNothing 설정 없이 실행
+	needNothing
+)
+
+// requirements Redis 연결 없이 실행 가능한 명령
+var requirements = map[string]requirement{
+	"token decode":    needNothing,
+	"key generate":    needNothing,
+	"key rotate":      needConfig,
+	"client register": needConfig,
+	"client list":     needConfig,
+	"client remove":   needConfig,
+}
+
+func main() {
+	output := flag.String("o", "table", "출력 형식 (table|json)")
+	timeout := flag.Duration("timeout", 10*time.Second, "명령 실행 제한 시간")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	group, name := flag.Arg(0), flag.Arg(1)
+	cmd, ok := commands[group][name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "알 수 없는 명령입니다: %s %s\n", group, name)
+		usage()
+		os.Exit(2)
+	}
+
+	out, err := newPrinter(os.Stdout, *output)
+	if err != nil {
+		fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	a := &app{out: out}
+	switch requirements[group+" "+name] {
+	case needConfig:
+		if a.cfg, err = config.LoadConfig(); err != nil {
+			fatal(fmt.Errorf("설정을 로드할 수 없습니다: %w", err))
+		}
+	case needRedis:
+		client, err := a.connect(ctx)
+		if err != nil {
+			fatal(err)
+		}
+		defer client.Close()
+	}
+
+	if err := cmd(ctx, a, flag.Args()[2:]); err != nil {
+		fatal(err)
+	}
+}
+
+// connect 설정을 로드하고 Redis 및 서비스 계층 초기화
+func (a *app) connect(ctx context.Context) (*redis.Client, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("설정을 로드할 수 없습니다: %w", err)
+	}
+	a.cfg = cfg
+
+	// 서버와 같은 키/claims로 발급하고 검증하도록 RS256 서명 키와 iss/aud도 적용
+	jwtOptions := []jwt.Option{
+		jwt.WithIssuer(cfg.JWT.Issuer),
+		jwt.WithAudience(cfg.JWT.Audience),
+	}
+	if cfg.JWT.PrivateKeyFile != "" {
+		signingKey, keyID, err := jwt.LoadSigningKey(cfg.JWT.PrivateKeyFile, cfg.JWT.KeyID)
+		if err != nil {
+			return nil, fmt.Errorf("JWT 서명 키 로드 실패: %w", err)
+		}
+		jwtOptions = append(jwtOptions, jwt.WithSigningKey(signingKey, keyID))
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Addr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	if _, err := client.Ping(ctx).Result(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("Redis 연결 실패: %w", err)
+	}
+
+	a.jwtService = jwt.NewJWTService(cfg.JWT.SecretKey, jwtOptions...)
+	a.tokenRepo = redisRepository.NewTokenRepository(client, a.jwtService)
+	a.authUseCase = usecase.NewAuthUseCase(a.tokenRepo, a.jwtService, nil, nil)
+
+	return client, nil
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `사용법: qauthctl [flags] <command> <subcommand> [args]
+
+명령:
+  token mint -user <id>          디버깅용 토큰 발급
+  token decode <token>           서명 검증 없이 토큰 디코딩
+  token verify <token>           서명 및 Redis 세션 검증
+  session list -user <id>        사용자 세션 조회
+  session revoke <token>         토큰 폐기
+  session revoke-all -user <id>  사용자의 모든 세션 폐기
+  key generate [-bytes 32]       새 HS256 시크릿 생성
+  key rotate [-bits 2048]        RS256 서명 키 파일 교체 (실행 중인 서버는 hot reload로 반영)
+  epoch bump [-user <id>]        이 시각 이전에 발급된 토큰 일괄 무효화 (전체 또는 사용자)
+  epoch show [-user <id>]        폐기 기준 시각 조회
+  client register -id <id> -ops <ops> [-auth api-key|hmac|cert] [-cert-identity <name>]
+                                 내부 API 호출자 등록 (시크릿은 한 번만 출력)
+  client list                    등록된 호출자 조회
+  client remove <id>             호출자 삭제
+
+flags:
+`)
+	flag.PrintDefaults()
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "오류: %v\n", err)
+	os.Exit(1)
+}
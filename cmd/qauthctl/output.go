@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// printer JSON 또는 테이블 형식 출력기
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+// newPrinter 출력 형식 검증 후 출력기 생성
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case "table", "json":
+		return &printer{w: w, format: format}, nil
+	default:
+		return nil, fmt.Errorf("지원하지 않는 출력 형식입니다: %s", format)
+	}
+}
+
+// Print 값 출력 (테이블 형식은 key/value 쌍으로 출력)
+func (p *printer) Print(v interface{}) error {
+	if p.format == "json" {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	fields, err := toFields(v)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	for _, k := range keys {
+		fmt.Fprintf(tw, "%s\t%v\n", strings.ToUpper(k), fields[k])
+	}
+	return tw.Flush()
+}
+
+// PrintRows 목록 출력 (테이블 형식은 columns 순서의 열로 출력)
+func (p *printer) PrintRows(columns []string, rows []map[string]interface{}) error {
+	if p.format == "json" {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = strings.ToUpper(c)
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		values := make([]string, len(columns))
+		for i, c := range columns {
+			values[i] = fmt.Sprint(row[c])
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	return tw.Flush()
+}
+
+// toFields 임의의 값을 JSON 필드 맵으로 변환
+func toFields(v interface{}) (map[string]interface{}, error) {
+	if m, ok := v.(map[string]interface{}); ok {
+		return m, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// 숫자 필드가 지수 표기로 출력되지 않도록 json.Number 사용
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var fields map[string]interface{}
+	if err := dec.Decode(&fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
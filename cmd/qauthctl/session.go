@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"time"
+)
+
+// sessionList 사용자의 현재 세션 조회
+func sessionList(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("session list", flag.ContinueOnError)
+	userID := fs.String("user", "", "조회할 사용자 ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *userID == "" {
+		return errors.New("-user 플래그가 필요합니다")
+	}
+
+	metadata, err := a.tokenRepo.FindByUserID(ctx, *userID)
+	if err != nil {
+		return err
+	}
+
+	return a.out.Print(map[string]interface{}{
+		"user_id":    metadata.UserID,
+		"issued_at":  time.Unix(metadata.IssuedAt, 0).Format(time.RFC3339),
+		"expires_at": time.Unix(metadata.ExpiresAt, 0).Format(time.RFC3339),
+		"ttl":        time.Until(time.Unix(metadata.ExpiresAt, 0)).Round(time.Second).String(),
+	})
+}
+
+// sessionRevoke 토큰 폐기
+func sessionRevoke(ctx context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return errors.New("폐기할 토큰이 필요합니다")
+	}
+
+	if err := a.authUseCase.RevokeToken(ctx, args[0]); err != nil {
+		return err
+	}
+	return a.out.Print(map[string]interface{}{"revoked": true})
+}
+
+// sessionRevokeAll 사용자의 모든 세션 폐기
+func sessionRevokeAll(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("session revoke-all", flag.ContinueOnError)
+	userID := fs.String("user", "", "세션을 폐기할 사용자 ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *userID == "" {
+		return errors.New("-user 플래그가 필요합니다")
+	}
+
+	if err := a.authUseCase.RevokeAllTokens(ctx, *userID); err != nil {
+		return err
+	}
+	return a.out.Print(map[string]interface{}{
+		"user_id": *userID,
+		"revoked": true,
+	})
+}
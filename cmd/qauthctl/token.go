@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"time"
+
+	"github.com/signalable/qauth/pkg/jwt"
+)
+
+// tokenMint 디버깅용 토큰 발급
+func tokenMint(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("token mint", flag.ContinueOnError)
+	userID := fs.String("user", "", "토큰을 발급할 사용자 ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *userID == "" {
+		return errors.New("-user 플래그가 필요합니다")
+	}
+
+	resp, err := a.authUseCase.CreateToken(ctx, *userID)
+	if err != nil {
+		return err
+	}
+	return a.out.Print(resp)
+}
+
+// tokenDecode 서명 검증 없이 토큰 디코딩
+func tokenDecode(_ context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return errors.New("디코딩할 토큰이 필요합니다")
+	}
+
+	header, claims, err := jwt.DecodeToken(args[0])
+	if err != nil {
+		return err
+	}
+
+	return a.out.Print(map[string]interface{}{
+		"header": header,
+		"claims": claims,
+	})
+}
+
+// tokenVerify 토큰 서명 및 Redis 세션 검증
+func tokenVerify(ctx context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return errors.New("검증할 토큰이 필요합니다")
+	}
+
+	result := map[string]interface{}{"valid": false}
+
+	userID, err := a.jwtService.ValidateToken(args[0])
+	if err != nil {
+		result["error"] = err.Error()
+		return a.out.Print(result)
+	}
+	result["user_id"] = userID
+
+	metadata, err := a.tokenRepo.Validate(ctx, args[0])
+	if err != nil {
+		result["error"] = err.Error()
+		return a.out.Print(result)
+	}
+
+	result["valid"] = true
+	result["issued_at"] = time.Unix(metadata.IssuedAt, 0).Format(time.RFC3339)
+	result["expires_at"] = time.Unix(metadata.ExpiresAt, 0).Format(time.RFC3339)
+	return a.out.Print(result)
+}
@@ -0,0 +1,420 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/signalable/qauth/internal/audit"
+	"github.com/signalable/qauth/internal/caller"
+	"github.com/signalable/qauth/internal/config"
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/pkg/hash"
+)
+
+// Prefix API 키 원문의 접두사 (JWT와 구분하고 유출 스캐너가 찾을 수 있도록 고정)
+const Prefix = "qak_"
+
+// indexKey 발급된 키 ID 목록
+const indexKey = "apikeys"
+
+// maxVerified bcrypt 비교 결과를 기억할 최대 키 수 (넘으면 비움)
+const maxVerified = 10000
+
+// touchScript 키 정보가 남아 있을 때만 마지막 사용 시각 기록
+// (인증 도중 폐기된 키의 hash가 TTL 없이 다시 생기지 않도록)
+var touchScript = redis.NewScript(`
+if redis.call('HEXISTS', KEYS[1], 'record') == 1 then
+  redis.call('HSET', KEYS[1], 'last_used_at', ARGV[1])
+  return 1
+end
+return 0
+`)
+
+// record Redis에 저장하는 키 정보 (비밀 값은 bcrypt 해시로만 보관)
+type record struct {
+	domain.APIKey
+	SecretHash string `json:"secret_hash"`
+}
+
+// Manager API 키 발급/조회/교체/폐기와 인증
+//
+// 키는 "qak_<ID>_<비밀 값>" 형식이며 ID로 저장된 키 정보를 찾고 비밀 값을 해시와 비교합니다.
+// 요청마다 bcrypt 비교를 하지 않도록 비교를 통과한 키는 저장된 해시와 함께 기억하며,
+// 교체나 폐기로 해시가 바뀌거나 키가 사라지면 다시 비교합니다.
+type Manager struct {
+	client *redis.Client
+	audit  *audit.Logger
+
+	mu       sync.Mutex
+	verified map[[sha256.Size]byte]string
+}
+
+// NewManager API 키 관리자 생성자
+func NewManager(client *redis.Client, auditLogger *audit.Logger) *Manager {
+	return &Manager{
+		client:   client,
+		audit:    auditLogger,
+		verified: make(map[[sha256.Size]byte]string),
+	}
+}
+
+// Issue 새 API 키 발급
+func (m *Manager) Issue(ctx context.Context, req domain.APIKeyRequest) (*domain.IssuedAPIKey, error) {
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	issued, err := m.issue(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	m.audit.Emit(ctx, audit.Event{
+		Type:     audit.EventAPIKeyIssued,
+		Actor:    actor(ctx),
+		Metadata: map[string]string{"key_id": issued.ID, "name": issued.Name, "user_id": issued.UserID},
+	})
+	return issued, nil
+}
+
+// List 발급된 API 키 목록 (userID가 있으면 해당 사용자의 키만)
+func (m *Manager) List(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	ids, err := m.client.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("API 키 목록 조회 실패: %w", err)
+	}
+
+	keys := make([]*domain.APIKey, 0, len(ids))
+	for _, id := range ids {
+		rec, err := m.load(ctx, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			// 만료되어 사라진 키는 목록에서도 제거
+			m.client.SRem(ctx, indexKey, id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if userID != "" && rec.UserID != userID {
+			continue
+		}
+		keys = append(keys, &rec.APIKey)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].CreatedAt.Before(keys[j].CreatedAt) })
+	return keys, nil
+}
+
+// Get API 키 정보 조회 (없으면 ErrNotFound)
+func (m *Manager) Get(ctx context.Context, id string) (*domain.APIKey, error) {
+	rec, err := m.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &rec.APIKey, nil
+}
+
+// Rotate 같은 설정으로 새 키를 발급하고 이전 키 폐기
+//
+// grace가 0보다 크면 이전 키는 바로 폐기하지 않고 grace 동안 (원래 만료가 더 이르면 그때까지) 함께 사용할 수 있습니다.
+func (m *Manager) Rotate(ctx context.Context, id string, grace time.Duration) (*domain.IssuedAPIKey, error) {
+	if grace < 0 {
+		return nil, fmt.Errorf("%w: 유예 시간은 0 이상이어야 합니다", domain.ErrInvalidRequest)
+	}
+	old, err := m.load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	issued, err := m.issue(ctx, domain.APIKeyRequest{
+		Name:       old.Name,
+		UserID:     old.UserID,
+		Scopes:     old.Scopes,
+		AllowedIPs: old.AllowedIPs,
+		ExpiresAt:  old.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if grace == 0 {
+		if err := m.delete(ctx, id); err != nil {
+			return nil, err
+		}
+	} else {
+		until := time.Now().Add(grace).UTC()
+		if old.ExpiresAt == nil || until.Before(*old.ExpiresAt) {
+			old.ExpiresAt = &until
+		}
+		if err := m.save(ctx, old); err != nil {
+			return nil, err
+		}
+	}
+
+	m.audit.Emit(ctx, audit.Event{
+		Type:  audit.EventAPIKeyRotated,
+		Actor: actor(ctx),
+		Metadata: map[string]string{
+			"key_id":     issued.ID,
+			"old_key_id": id,
+			"user_id":    issued.UserID,
+			"grace":      grace.String(),
+		},
+	})
+	return issued, nil
+}
+
+// Revoke API 키 폐기 (없으면 ErrNotFound)
+func (m *Manager) Revoke(ctx context.Context, id string) error {
+	rec, err := m.load(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := m.delete(ctx, id); err != nil {
+		return err
+	}
+
+	m.audit.Emit(ctx, audit.Event{
+		Type:     audit.EventAPIKeyRevoked,
+		Actor:    actor(ctx),
+		Metadata: map[string]string{"key_id": id, "name": rec.Name, "user_id": rec.UserID},
+	})
+	return nil
+}
+
+// Authenticate API 키 인증 (usecase.APIKeyAuthenticator 구현)
+//
+// API 키 형식이 아니면 found가 false입니다. 만료된 키는 ErrExpiredToken, 없거나 틀린 키와
+// 허용되지 않은 IP(context의 클라이언트 IP)에서의 사용은 ErrInvalidToken을 반환하며,
+// 성공하면 마지막 사용 시각을 기록합니다.
+func (m *Manager) Authenticate(ctx context.Context, credential string) (*domain.APIKey, bool, error) {
+	id, secret, ok := parse(credential)
+	if !ok {
+		return nil, false, nil
+	}
+
+	rec, err := m.load(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, true, fmt.Errorf("%w: 등록되지 않은 API 키", domain.ErrInvalidToken)
+	}
+	if err != nil {
+		return nil, true, err
+	}
+	if !m.verify(credential, secret, rec.SecretHash) {
+		return nil, true, fmt.Errorf("%w: API 키 불일치", domain.ErrInvalidToken)
+	}
+
+	now := time.Now()
+	if rec.ExpiresAt != nil && now.After(*rec.ExpiresAt) {
+		return nil, true, domain.ErrExpiredToken
+	}
+	if !allowed(rec.AllowedIPs, domain.ClientIPFromContext(ctx)) {
+		slog.InfoContext(ctx, "허용되지 않은 IP의 API 키 사용 거부", slog.String("key_id", id), slog.String("ip", domain.ClientIPFromContext(ctx)))
+		return nil, true, fmt.Errorf("%w: 허용되지 않은 IP", domain.ErrInvalidToken)
+	}
+
+	// 마지막 사용 시각 기록 실패는 인증 결과에 영향을 주지 않음
+	if err := touchScript.Run(ctx, m.client, []string{key(id)}, now.UnixMilli()).Err(); err != nil {
+		slog.WarnContext(ctx, "API 키 사용 시각 기록 실패", slog.String("key_id", id), slog.Any("error", err))
+	}
+	used := now.UTC()
+	rec.LastUsedAt = &used
+	return &rec.APIKey, true, nil
+}
+
+// actor 키를 관리한 내부 호출자 ID (감사 이벤트의 Actor, 키 소유자는 metadata의 user_id)
+func actor(ctx context.Context) string {
+	if c := caller.FromContext(ctx); c != nil {
+		return c.ID
+	}
+	return ""
+}
+
+// issue 키를 생성해 저장 (요청은 검증된 상태)
+func (m *Manager) issue(ctx context.Context, req domain.APIKeyRequest) (*domain.IssuedAPIKey, error) {
+	id, err := randomString(6, hex.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomString(32, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+	secretHash, err := hash.GenerateHash(secret)
+	if err != nil {
+		return nil, fmt.Errorf("API 키 해싱 실패: %w", err)
+	}
+
+	rec := &record{
+		APIKey: domain.APIKey{
+			ID:         id,
+			Prefix:     Prefix + id,
+			Name:       req.Name,
+			UserID:     req.UserID,
+			Scopes:     req.Scopes,
+			AllowedIPs: req.AllowedIPs,
+			CreatedAt:  time.Now().UTC(),
+			ExpiresAt:  req.ExpiresAt,
+		},
+		SecretHash: secretHash,
+	}
+	if err := m.save(ctx, rec); err != nil {
+		return nil, err
+	}
+
+	return &domain.IssuedAPIKey{
+		APIKey: rec.APIKey,
+		Key:    rec.Prefix + "_" + secret,
+	}, nil
+}
+
+// save 키 정보 저장 (만료 시각이 있으면 그때 Redis에서도 삭제)
+func (m *Manager) save(ctx context.Context, rec *record) error {
+	stored := *rec
+	stored.LastUsedAt = nil
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("API 키 직렬화 실패: %w", err)
+	}
+
+	pipe := m.client.TxPipeline()
+	pipe.HSet(ctx, key(rec.ID), "record", data)
+	if rec.ExpiresAt != nil {
+		pipe.ExpireAt(ctx, key(rec.ID), *rec.ExpiresAt)
+	}
+	pipe.SAdd(ctx, indexKey, rec.ID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("API 키 저장 실패: %w", err)
+	}
+	return nil
+}
+
+func (m *Manager) load(ctx context.Context, id string) (*record, error) {
+	fields, err := m.client.HGetAll(ctx, key(id)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("API 키 조회 실패: %w", err)
+	}
+	if fields["record"] == "" {
+		return nil, domain.ErrNotFound
+	}
+
+	var rec record
+	if err := json.Unmarshal([]byte(fields["record"]), &rec); err != nil {
+		return nil, fmt.Errorf("API 키 역직렬화 실패: %w", err)
+	}
+	if lastUsed, err := strconv.ParseInt(fields["last_used_at"], 10, 64); err == nil {
+		t := time.UnixMilli(lastUsed).UTC()
+		rec.LastUsedAt = &t
+	}
+	return &rec, nil
+}
+
+func (m *Manager) delete(ctx context.Context, id string) error {
+	pipe := m.client.TxPipeline()
+	pipe.Del(ctx, key(id))
+	pipe.SRem(ctx, indexKey, id)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("API 키 삭제 실패: %w", err)
+	}
+	return nil
+}
+
+// verify 비밀 값과 저장된 해시 비교 (같은 해시로 이미 통과한 키는 bcrypt 생략)
+func (m *Manager) verify(credential, secret, secretHash string) bool {
+	sum := sha256.Sum256([]byte(credential))
+
+	m.mu.Lock()
+	known := m.verified[sum] == secretHash
+	m.mu.Unlock()
+	if known {
+		return true
+	}
+
+	if !hash.CompareHash(secretHash, secret) {
+		return false
+	}
+
+	m.mu.Lock()
+	if len(m.verified) >= maxVerified {
+		m.verified = make(map[[sha256.Size]byte]string)
+	}
+	m.verified[sum] = secretHash
+	m.mu.Unlock()
+	return true
+}
+
+// validate 발급 요청 검증
+func validate(req domain.APIKeyRequest) error {
+	if req.UserID == "" || req.Name == "" {
+		return fmt.Errorf("%w: name과 user_id가 필요합니다", domain.ErrInvalidRequest)
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("%w: expires_at은 미래 시각이어야 합니다", domain.ErrInvalidRequest)
+	}
+	for _, scope := range req.Scopes {
+		if scope == "" || strings.ContainsAny(scope, " \t") {
+			return fmt.Errorf("%w: 잘못된 scope %q", domain.ErrInvalidRequest, scope)
+		}
+	}
+	for _, ip := range req.AllowedIPs {
+		if _, err := config.ParseNetwork(ip); err != nil {
+			return fmt.Errorf("%w: allowed_ips는 IP 또는 CIDR이어야 합니다 (%q)", domain.ErrInvalidRequest, ip)
+		}
+	}
+	return nil
+}
+
+// allowed 클라이언트 IP가 허용 목록에 있는지 여부 (목록이 비어 있으면 항상 허용)
+func allowed(allowedIPs []string, clientIP string) bool {
+	if len(allowedIPs) == 0 {
+		return true
+	}
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return false
+	}
+	for _, value := range allowedIPs {
+		network, err := config.ParseNetwork(value)
+		if err == nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parse 키 원문을 ID와 비밀 값으로 분리 (API 키 형식이 아니면 false)
+func parse(credential string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(credential, Prefix)
+	if !ok {
+		return "", "", false
+	}
+	id, secret, ok := strings.Cut(rest, "_")
+	return id, secret, ok && id != "" && secret != ""
+}
+
+func randomString(n int, encode func([]byte) string) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("API 키 생성 실패: %w", err)
+	}
+	return encode(buf), nil
+}
+
+func key(id string) string {
+	return "apikey:" + id
+}
@@ -0,0 +1,37 @@
+package apikey
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/signalable/qauth/internal/domain"
+)
+
+// HeaderAPIKey API 키 전용 요청 헤더 (Authorization: Bearer <키> 대신 사용 가능)
+const HeaderAPIKey = "X-API-Key"
+
+// headerForwardedFor 프록시가 클라이언트 IP를 전달하는 헤더
+const headerForwardedFor = "X-Forwarded-For"
+
+// AttachClientIP 요청 클라이언트 IP를 context에 추가 (API 키 IP 제한 확인용)
+//
+// forwarded가 true면 프록시나 내부 호출자가 전달한 X-Forwarded-For의 마지막 주소
+// (직전 프록시가 본 클라이언트)를 사용하고, 헤더가 없으면 연결 주소를 사용합니다.
+func AttachClientIP(r *http.Request, forwarded bool) *http.Request {
+	ip := ""
+	if forwarded {
+		if values := r.Header.Values(headerForwardedFor); len(values) > 0 {
+			list := strings.Split(values[len(values)-1], ",")
+			ip = strings.TrimSpace(list[len(list)-1])
+		}
+	}
+	if ip == "" {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		ip = host
+	}
+	return r.WithContext(domain.WithClientIP(r.Context(), ip))
+}
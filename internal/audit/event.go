@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// EventType 감사 이벤트 종류
+type EventType string
+
+const (
+	EventTokenIssued           EventType = "token.issued"
+	EventTokenRefreshed        EventType = "token.refreshed"
+	EventTokenRevoked          EventType = "token.revoked"
+	EventTokenRevokedAll       EventType = "token.revoked_all"
+	EventTokenExchanged        EventType = "token.exchanged"
+	EventTokenValidationFailed EventType = "token.validation_failed"
+	EventLoginSucceeded        EventType = "login.succeeded"
+	EventLoginFailed           EventType = "login.failed"
+	EventLockoutLocked         EventType = "lockout.locked"
+	EventLockoutCleared        EventType = "lockout.cleared"
+	EventAPIKeyIssued          EventType = "api_key.issued"
+	EventAPIKeyRotated         EventType = "api_key.rotated"
+	EventAPIKeyRevoked         EventType = "api_key.revoked"
+	EventImpersonationStarted  EventType = "impersonation.started"
+	EventImpersonationUsed     EventType = "impersonation.used"
+	EventImpersonationRevoked  EventType = "impersonation.revoked"
+	EventConfigReloaded        EventType = "config.reloaded"
+	EventConfigReloadFailed    EventType = "config.reload_failed"
+)
+
+// Event 감사 이벤트
+type Event struct {
+	ID        string            `json:"id"`
+	Type      EventType         `json:"type"`
+	Time      time.Time         `json:"time"`
+	Actor     string            `json:"actor,omitempty"`
+	Client    string            `json:"client,omitempty"`
+	IP        string            `json:"ip,omitempty"`
+	UserAgent string            `json:"user_agent,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
+	Reason    string            `json:"reason,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// RequestInfo 감사 이벤트에 기록할 요청 출처 정보
+type RequestInfo struct {
+	IP        string
+	UserAgent string
+	Client    string
+
+	// User 토큰/API 키로 인증된 사용자 ID (인증 미들웨어 통과 후 설정)
+	User string
+}
+
+type requestInfoKey struct{}
+
+// WithRequestInfo context에 요청 출처 정보 추가
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext context에서 요청 출처 정보 조회
+func RequestInfoFromContext(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info
+}
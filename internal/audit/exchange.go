@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/logger"
+	"github.com/signalable/qauth/internal/usecase"
+)
+
+type auditedTokenExchangeUseCase struct {
+	next   usecase.TokenExchangeUseCase
+	logger *Logger
+}
+
+// InstrumentTokenExchangeUseCase 토큰 교환 결과를 감사 이벤트로 기록하는 TokenExchangeUseCase 래퍼
+func InstrumentTokenExchangeUseCase(next usecase.TokenExchangeUseCase, l *Logger) usecase.TokenExchangeUseCase {
+	return &auditedTokenExchangeUseCase{
+		next:   next,
+		logger: l,
+	}
+}
+
+func (uc *auditedTokenExchangeUseCase) ExchangeToken(ctx context.Context, req *domain.TokenExchangeRequest) (*domain.TokenExchangeResponse, error) {
+	resp, err := uc.next.ExchangeToken(ctx, req)
+
+	event := Event{
+		Type:  EventTokenExchanged,
+		Actor: subject(req.SubjectToken),
+		Metadata: map[string]string{
+			"subject_token_fp": logger.Fingerprint(req.SubjectToken),
+			"audience":         req.Audience,
+		},
+	}
+	if req.ActorToken != "" {
+		event.Metadata["actor_token_fp"] = logger.Fingerprint(req.ActorToken)
+		event.Metadata["actor"] = subject(req.ActorToken)
+	}
+
+	if err != nil {
+		code := domain.ErrorCode(err)
+		if code == domain.CodeInternalError {
+			return resp, err
+		}
+		event.Reason = code
+	} else {
+		event.Metadata["token_fp"] = logger.Fingerprint(resp.AccessToken)
+		event.Metadata["scope"] = resp.Scope
+	}
+
+	uc.logger.Emit(ctx, event)
+	return resp, err
+}
@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/signalable/qauth/internal/requestid"
+)
+
+// Sink 감사 이벤트 저장소
+type Sink interface {
+	Write(ctx context.Context, event Event) error
+	Close() error
+}
+
+// Logger 감사 이벤트를 모든 sink에 기록
+//
+// relays는 이벤트를 보관하지 않고 외부로 전달만 하는 sink(웹훅 등)로,
+// Record의 기록 성공으로 치지 않습니다.
+type Logger struct {
+	sinks  []Sink
+	relays []Sink
+}
+
+// NewLogger 감사 로거 생성자
+func NewLogger(sinks ...Sink) *Logger {
+	return &Logger{sinks: sinks}
+}
+
+// Emit 요청 정보를 채워 이벤트 기록
+//
+// 감사 기록 실패가 인증 흐름을 막지 않도록 sink 에러는 로그로만 남깁니다.
+func (l *Logger) Emit(ctx context.Context, event Event) {
+	if l == nil || len(l.sinks)+len(l.relays) == 0 {
+		return
+	}
+	l.write(ctx, event)
+}
+
+// Record 반드시 남겨야 하는 이벤트 기록 (보관용 sink에 하나도 기록하지 못하면 에러)
+//
+// 감사 기록이 없으면 진행하면 안 되는 작업(관리자 대리 로그인 등)에 사용합니다.
+// 웹훅처럼 전달만 하는 sink는 구독자가 없거나 전송이 보류되어도 성공하므로 제외합니다.
+func (l *Logger) Record(ctx context.Context, event Event) error {
+	if l == nil || len(l.sinks) == 0 {
+		return errors.New("보관용 감사 sink(stdout/file/redis)가 설정되지 않았습니다")
+	}
+	if written, errs := l.write(ctx, event); written == 0 {
+		return fmt.Errorf("모든 감사 sink 기록 실패: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// write 이벤트를 모든 sink에 기록하고 기록에 성공한 보관용 sink 수와 sink 에러 반환
+func (l *Logger) write(ctx context.Context, event Event) (int, []error) {
+	if event.ID == "" {
+		event.ID = newEventID()
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	if event.RequestID == "" {
+		event.RequestID = requestid.FromContext(ctx)
+	}
+
+	info := RequestInfoFromContext(ctx)
+	if event.IP == "" {
+		event.IP = info.IP
+	}
+	if event.UserAgent == "" {
+		event.UserAgent = info.UserAgent
+	}
+	if event.Client == "" {
+		event.Client = info.Client
+	}
+
+	// 요청이 취소되어도 감사 기록은 남겨야 함
+	ctx = context.WithoutCancel(ctx)
+
+	written := 0
+	var errs []error
+	for _, sink := range l.sinks {
+		if err := l.writeSink(ctx, sink, event); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		written++
+	}
+	for _, relay := range l.relays {
+		l.writeSink(ctx, relay, event)
+	}
+	return written, errs
+}
+
+func (l *Logger) writeSink(ctx context.Context, sink Sink, event Event) error {
+	err := sink.Write(ctx, event)
+	if err != nil {
+		slog.ErrorContext(ctx, "감사 이벤트 기록 실패",
+			slog.String("event_id", event.ID),
+			slog.String("event_type", string(event.Type)),
+			slog.Any("error", err),
+		)
+	}
+	return err
+}
+
+// Close 모든 sink 종료
+func (l *Logger) Close() error {
+	var errs []error
+	for _, sink := range append(l.sinks, l.relays...) {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func newEventID() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
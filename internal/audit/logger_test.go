@@ -0,0 +1,50 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type stubSink struct {
+	err    error
+	writes int
+}
+
+func (s *stubSink) Write(context.Context, Event) error {
+	s.writes++
+	return s.err
+}
+
+func (s *stubSink) Close() error { return nil }
+
+func TestRecordIgnoresRelays(t *testing.T) {
+	relay := &stubSink{}
+	failing := &stubSink{err: errors.New("disk full")}
+
+	tests := []struct {
+		name    string
+		logger  *Logger
+		wantErr bool
+	}{
+		{"보관용 sink 없음", &Logger{relays: []Sink{relay}}, true},
+		{"보관용 sink 실패", &Logger{sinks: []Sink{failing}, relays: []Sink{relay}}, true},
+		{"보관용 sink 하나 성공", &Logger{sinks: []Sink{failing, &stubSink{}}, relays: []Sink{relay}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.logger.Record(context.Background(), Event{Type: "test"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	err := (&Logger{sinks: []Sink{failing}}).Record(context.Background(), Event{Type: "test"})
+	if !errors.Is(err, failing.err) {
+		t.Errorf("sink 에러가 반환되어야 합니다: %v", err)
+	}
+	if relay.writes != 2 {
+		t.Errorf("relay writes = %d, want 2", relay.writes)
+	}
+}
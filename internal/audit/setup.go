@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/signalable/qauth/internal/config"
+)
+
+// Setup 설정된 sink와 전달용 sink(웹훅 등)로 감사 로거 생성
+func Setup(cfg config.AuditConfig, client *redis.Client, relays ...Sink) (*Logger, error) {
+	var sinks []Sink
+	for _, name := range cfg.Sinks {
+		switch name {
+		case "stdout":
+			sinks = append(sinks, NewWriterSink(os.Stdout))
+		case "file":
+			sink, err := NewFileSink(cfg.FilePath, cfg.FileMaxBytes, cfg.FileMaxBackups)
+			if err != nil {
+				closeSinks(append(sinks, relays...))
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		case "redis":
+			sinks = append(sinks, NewRedisStreamSink(client, cfg.RedisStream, cfg.RedisMaxLen))
+		default:
+			closeSinks(append(sinks, relays...))
+			return nil, fmt.Errorf("지원하지 않는 감사 sink: %s", name)
+		}
+	}
+	return &Logger{sinks: sinks, relays: relays}, nil
+}
+
+func closeSinks(sinks []Sink) {
+	for _, sink := range sinks {
+		sink.Close()
+	}
+}
@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FileSink 크기 기준으로 회전하는 JSON Lines 파일 sink
+//
+// 파일은 append 전용으로 열고, 회전 시 기존 파일을 타임스탬프가 붙은 이름으로 옮깁니다.
+type FileSink struct {
+	mu         sync.Mutex
+	path       string
+	maxBytes   int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// NewFileSink 파일 sink 생성자 (maxBackups가 0이면 회전된 파일을 지우지 않음)
+func NewFileSink(path string, maxBytes int64, maxBackups int) (*FileSink, error) {
+	s := &FileSink{
+		path:       path,
+		maxBytes:   maxBytes,
+		maxBackups: maxBackups,
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileSink) Write(_ context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.maxBytes > 0 && s.size+int64(len(data)) > s.maxBytes && s.size > 0 {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(data)
+	s.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("감사 로그 기록 실패: %w", err)
+	}
+	return nil
+}
+
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
+
+func (s *FileSink) open() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return fmt.Errorf("감사 로그 디렉터리 생성 실패: %w", err)
+	}
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return fmt.Errorf("감사 로그 파일 열기 실패: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("감사 로그 파일 조회 실패: %w", err)
+	}
+
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+// rotate 현재 파일을 백업 이름으로 옮기고 새 파일 열기
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("감사 로그 파일 닫기 실패: %w", err)
+	}
+
+	backup := fmt.Sprintf("%s.%s", s.path, time.Now().UTC().Format("20060102T150405.000000000"))
+	if err := os.Rename(s.path, backup); err != nil {
+		return fmt.Errorf("감사 로그 회전 실패: %w", err)
+	}
+
+	if err := s.open(); err != nil {
+		return err
+	}
+
+	s.prune()
+	return nil
+}
+
+// prune 보관 개수를 넘는 오래된 백업 삭제
+func (s *FileSink) prune() {
+	if s.maxBackups <= 0 {
+		return
+	}
+
+	backups, err := filepath.Glob(s.path + ".*")
+	if err != nil || len(backups) <= s.maxBackups {
+		return
+	}
+
+	// 타임스탬프 접미사라 이름순 정렬이 시간순
+	sort.Strings(backups)
+	for _, old := range backups[:len(backups)-s.maxBackups] {
+		os.Remove(old)
+	}
+}
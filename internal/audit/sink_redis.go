@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisStreamSink Redis Streams에 추가 전용으로 기록하는 sink
+type RedisStreamSink struct {
+	client *redis.Client
+	stream string
+	maxLen int64
+}
+
+// NewRedisStreamSink Redis Streams sink 생성자 (maxLen이 0이면 길이 제한 없음)
+func NewRedisStreamSink(client *redis.Client, stream string, maxLen int64) *RedisStreamSink {
+	return &RedisStreamSink{
+		client: client,
+		stream: stream,
+		maxLen: maxLen,
+	}
+}
+
+func (s *RedisStreamSink) Write(ctx context.Context, event Event) error {
+	metadata, err := json.Marshal(event.Metadata)
+	if err != nil {
+		return err
+	}
+
+	args := &redis.XAddArgs{
+		Stream: s.stream,
+		Values: map[string]interface{}{
+			"id":         event.ID,
+			"type":       string(event.Type),
+			"time":       event.Time.Format(time.RFC3339Nano),
+			"actor":      event.Actor,
+			"client":     event.Client,
+			"ip":         event.IP,
+			"user_agent": event.UserAgent,
+			"request_id": event.RequestID,
+			"reason":     event.Reason,
+			"metadata":   string(metadata),
+		},
+	}
+	if s.maxLen > 0 {
+		args.MaxLen = s.maxLen
+		args.Approx = true
+	}
+
+	if err := s.client.XAdd(ctx, args).Err(); err != nil {
+		return fmt.Errorf("감사 스트림 기록 실패: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisStreamSink) Close() error {
+	return nil
+}
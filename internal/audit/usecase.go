@@ -0,0 +1,131 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/logger"
+	"github.com/signalable/qauth/internal/usecase"
+	"github.com/signalable/qauth/pkg/jwt"
+)
+
+type auditedAuthUseCase struct {
+	next   usecase.AuthUseCase
+	logger *Logger
+}
+
+// InstrumentAuthUseCase 토큰 발급/갱신/폐기/검증 실패를 감사 이벤트로 기록하는 AuthUseCase 래퍼
+func InstrumentAuthUseCase(next usecase.AuthUseCase, l *Logger) usecase.AuthUseCase {
+	return &auditedAuthUseCase{
+		next:   next,
+		logger: l,
+	}
+}
+
+func (uc *auditedAuthUseCase) CreateToken(ctx context.Context, userID string) (*domain.AuthResponse, error) {
+	resp, err := uc.next.CreateToken(ctx, userID)
+	if err == nil {
+		uc.logger.Emit(ctx, Event{
+			Type:     EventTokenIssued,
+			Actor:    userID,
+			Metadata: map[string]string{"token_fp": logger.Fingerprint(resp.AccessToken)},
+		})
+	}
+	return resp, err
+}
+
+func (uc *auditedAuthUseCase) ValidateToken(ctx context.Context, token string) (*domain.TokenValidationResponse, error) {
+	resp, err := uc.next.ValidateToken(ctx, token)
+	if err != nil {
+		uc.logger.Emit(ctx, Event{
+			Type:     EventTokenValidationFailed,
+			Actor:    subject(token),
+			Reason:   domain.ErrorCode(err),
+			Metadata: map[string]string{"token_fp": logger.Fingerprint(token)},
+		})
+		return resp, err
+	}
+
+	// 대리 로그인 토큰은 사용할 때마다 기록
+	if resp.Impersonation {
+		uc.logger.Emit(ctx, Event{
+			Type:  EventImpersonationUsed,
+			Actor: resp.Impersonator(),
+			Metadata: map[string]string{
+				"user_id":  resp.UserID,
+				"token_fp": logger.Fingerprint(token),
+			},
+		})
+	}
+	return resp, err
+}
+
+func (uc *auditedAuthUseCase) RevokeToken(ctx context.Context, token string) error {
+	err := uc.next.RevokeToken(ctx, token)
+	uc.emitResult(ctx, EventTokenRevoked, subject(token), token, err)
+	return err
+}
+
+func (uc *auditedAuthUseCase) RevokeAllTokens(ctx context.Context, userID string) error {
+	err := uc.next.RevokeAllTokens(ctx, userID)
+	if err == nil {
+		uc.logger.Emit(ctx, Event{
+			Type:  EventTokenRevokedAll,
+			Actor: userID,
+		})
+	}
+	return err
+}
+
+func (uc *auditedAuthUseCase) RefreshToken(ctx context.Context, oldToken string) (*domain.AuthResponse, error) {
+	resp, err := uc.next.RefreshToken(ctx, oldToken)
+	if err != nil {
+		uc.emitResult(ctx, EventTokenRefreshed, subject(oldToken), oldToken, err)
+		return resp, err
+	}
+
+	uc.logger.Emit(ctx, Event{
+		Type:  EventTokenRefreshed,
+		Actor: subject(oldToken),
+		Metadata: map[string]string{
+			"old_token_fp": logger.Fingerprint(oldToken),
+			"token_fp":     logger.Fingerprint(resp.AccessToken),
+		},
+	})
+	return resp, err
+}
+
+func (uc *auditedAuthUseCase) GetTokenMetadata(ctx context.Context, token string) (*domain.TokenMetadata, error) {
+	return uc.next.GetTokenMetadata(ctx, token)
+}
+
+// emitResult 작업 결과 기록 (토큰 문제로 실패하면 검증 실패 이벤트로 기록)
+func (uc *auditedAuthUseCase) emitResult(ctx context.Context, eventType EventType, actor, token string, err error) {
+	event := Event{
+		Type:     eventType,
+		Actor:    actor,
+		Metadata: map[string]string{"token_fp": logger.Fingerprint(token)},
+	}
+
+	if err != nil {
+		code := domain.ErrorCode(err)
+		if code == domain.CodeInternalError {
+			return
+		}
+		event.Type = EventTokenValidationFailed
+		event.Reason = code
+		event.Metadata["operation"] = string(eventType)
+	}
+
+	uc.logger.Emit(ctx, event)
+}
+
+// subject 감사 기록용 사용자 ID (서명 검증 전 값이므로 식별 용도로만 사용)
+func subject(token string) string {
+	_, claims, err := jwt.DecodeToken(token)
+	if err != nil {
+		return ""
+	}
+	userID, _ := claims["user_id"].(string)
+	return userID
+}
@@ -0,0 +1,240 @@
+package caller
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/tlsconfig"
+)
+
+// 호출자 인증 헤더
+const (
+	HeaderCaller    = "X-QAuth-Caller"
+	HeaderSignature = "X-QAuth-Signature"
+	HeaderAPIKey    = "X-QAuth-Caller-Key"
+)
+
+// Request 전송 방식(HTTP, gRPC)과 무관한 인증 대상 요청
+type Request struct {
+	Method string
+	// URI 경로와 쿼리 (gRPC는 전체 메서드 이름)
+	URI    string
+	Host   string
+	Header http.Header
+	Body   []byte
+
+	// CertIdentity 검증된 클라이언트 인증서의 호출자 식별자 (없으면 빈 문자열)
+	CertIdentity string
+
+	// RemoteAddr 연결 주소 (host:port 또는 IP)
+	RemoteAddr string
+}
+
+// Authenticator 한 가지 방식으로 호출자를 인증
+type Authenticator interface {
+	// Authenticate 이 방식의 자격 증명이 없으면 (nil, false, nil), 있으면 검증 결과 반환
+	Authenticate(ctx context.Context, req *Request) (*Caller, bool, error)
+}
+
+// Auth 인증 방식을 차례로 시도해 호출자를 식별하고 작업 허용 여부를 확인
+//
+// 처음으로 자격 증명이 발견된 방식의 결과만 사용하며, 틀린 자격 증명은 다음 방식으로 넘어가지 않습니다.
+type Auth struct {
+	registry       *Registry
+	tls            *tlsconfig.Manager
+	authenticators []Authenticator
+}
+
+// NewAuth Auth 생성자 (tls가 nil이면 TLS 미사용으로 간주)
+func NewAuth(registry *Registry, tls *tlsconfig.Manager, authenticators ...Authenticator) *Auth {
+	return &Auth{
+		registry:       registry,
+		tls:            tls,
+		authenticators: authenticators,
+	}
+}
+
+// Authorize 호출자 인증 후 작업 허용 여부 확인
+//
+// 인증 실패는 ErrAuthenticationFailed, 허용되지 않은 작업은 ErrUnauthorized를 반환합니다.
+// 호출자 목록이 비어 있으면 (development) localhost에서 연결한 요청만 허용하며,
+// mTLS 설정 시에는 검증된 인증서도 요구합니다.
+func (a *Auth) Authorize(ctx context.Context, req *Request, operation string) (*Caller, error) {
+	if a.registry.Empty() {
+		if !loopback(req.RemoteAddr) {
+			slog.WarnContext(ctx, "호출자 목록이 없어 localhost 외의 내부 API 요청 거부", slog.String("remote_addr", req.RemoteAddr))
+			return nil, domain.ErrAuthenticationFailed
+		}
+		if a.tls.MutualTLS() && req.CertIdentity == "" {
+			return nil, domain.ErrAuthenticationFailed
+		}
+		return &Caller{ID: req.CertIdentity}, nil
+	}
+
+	for _, authenticator := range a.authenticators {
+		c, found, err := authenticator.Authenticate(ctx, req)
+		if !found {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !c.Allows(operation) {
+			return c, domain.ErrUnauthorized
+		}
+		return c, nil
+	}
+	return nil, domain.ErrAuthenticationFailed
+}
+
+// loopback 연결 주소가 localhost인지 여부
+func loopback(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// maxNonceLength HMAC 서명 nonce 최대 길이
+const maxNonceLength = 128
+
+// Sign HMAC 요청 서명 헤더 값 생성
+//
+// 형식은 "t=<unix 초>,n=<nonce>,v1=<hex>"이며,
+// v1은 "<t>.<nonce>.<메서드>.<경로와 쿼리>.<본문>"에 대한 HMAC-SHA256입니다.
+// nonce는 요청마다 새로 만든 임의 값이어야 합니다.
+func Sign(secret string, timestamp time.Time, nonce, method, uri string, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + t + ",n=" + nonce + ",v1=" + signature(secret, t, nonce, method, uri, body)
+}
+
+func signature(secret, t, nonce, method, uri string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t + "." + nonce + "." + strings.ToUpper(method) + "." + uri + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// HMACAuthenticator X-QAuth-Caller와 X-QAuth-Signature 헤더의 공유 시크릿 서명 인증
+//
+// 같은 nonce는 허용 오차의 두 배 동안 다시 쓸 수 없으므로 캡처한 요청을 재전송할 수 없습니다.
+type HMACAuthenticator struct {
+	registry  *Registry
+	nonces    NonceStore
+	tolerance time.Duration
+}
+
+// NewHMACAuthenticator HMAC 인증 생성자 (tolerance는 서명 시각 허용 오차)
+func NewHMACAuthenticator(registry *Registry, nonces NonceStore, tolerance time.Duration) *HMACAuthenticator {
+	return &HMACAuthenticator{
+		registry:  registry,
+		nonces:    nonces,
+		tolerance: tolerance,
+	}
+}
+
+func (a *HMACAuthenticator) Authenticate(ctx context.Context, req *Request) (*Caller, bool, error) {
+	header := req.Header.Get(HeaderSignature)
+	if header == "" {
+		return nil, false, nil
+	}
+
+	c, ok := a.registry.Lookup(req.Header.Get(HeaderCaller))
+	if !ok || c.HMACSecret == "" {
+		return nil, true, domain.ErrAuthenticationFailed
+	}
+
+	var t, nonce, v1 string
+	for _, part := range strings.Split(header, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch k {
+		case "t":
+			t = v
+		case "n":
+			nonce = v
+		case "v1":
+			v1 = v
+		}
+	}
+	unix, err := strconv.ParseInt(t, 10, 64)
+	if err != nil || v1 == "" || nonce == "" || len(nonce) > maxNonceLength {
+		return nil, true, domain.ErrAuthenticationFailed
+	}
+	if skew := time.Since(time.Unix(unix, 0)); skew > a.tolerance || skew < -a.tolerance {
+		return nil, true, domain.ErrAuthenticationFailed
+	}
+
+	expected := signature(c.HMACSecret, t, nonce, req.Method, req.URI, req.Body)
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(v1))) {
+		return nil, true, domain.ErrAuthenticationFailed
+	}
+
+	// 서명이 맞는 요청만 nonce를 기록 (위조 요청으로 정상 호출자의 nonce를 선점할 수 없도록)
+	fresh, err := a.nonces.Claim(ctx, c.ID, nonce, 2*a.tolerance)
+	if err != nil {
+		return nil, true, err
+	}
+	if !fresh {
+		slog.WarnContext(ctx, "재전송된 HMAC 서명 거부", slog.String("caller", c.ID))
+		return nil, true, domain.ErrAuthenticationFailed
+	}
+	return c, true, nil
+}
+
+// APIKeyAuthenticator X-QAuth-Caller-Key 헤더의 API 키 인증
+type APIKeyAuthenticator struct {
+	registry *Registry
+}
+
+// NewAPIKeyAuthenticator API 키 인증 생성자
+func NewAPIKeyAuthenticator(registry *Registry) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{
+		registry: registry,
+	}
+}
+
+func (a *APIKeyAuthenticator) Authenticate(_ context.Context, req *Request) (*Caller, bool, error) {
+	key := req.Header.Get(HeaderAPIKey)
+	if key == "" {
+		return nil, false, nil
+	}
+	c, ok := a.registry.ByAPIKey(key)
+	if !ok {
+		return nil, true, domain.ErrAuthenticationFailed
+	}
+	return c, true, nil
+}
+
+// ClientCertAuthenticator 검증된 클라이언트 인증서 식별자 인증 (mTLS)
+type ClientCertAuthenticator struct {
+	registry *Registry
+}
+
+// NewClientCertAuthenticator 클라이언트 인증서 인증 생성자
+func NewClientCertAuthenticator(registry *Registry) *ClientCertAuthenticator {
+	return &ClientCertAuthenticator{
+		registry: registry,
+	}
+}
+
+func (a *ClientCertAuthenticator) Authenticate(_ context.Context, req *Request) (*Caller, bool, error) {
+	if req.CertIdentity == "" {
+		return nil, false, nil
+	}
+	c, ok := a.registry.ByCertIdentity(req.CertIdentity)
+	if !ok {
+		return nil, true, domain.ErrAuthenticationFailed
+	}
+	return c, true, nil
+}
@@ -0,0 +1,250 @@
+package caller
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// 내부 API 작업 (호출자별 허용 목록에 사용)
+const (
+	OpTokenCreate        = "token.create"
+	OpTokenValidate      = "token.validate"
+	OpTokenExchange      = "token.exchange"
+	OpLoginAttempts      = "login.attempts"
+	OpAdminWebhooks      = "admin.webhooks"
+	OpAdminLockouts      = "admin.lockouts"
+	OpAdminAPIKeys       = "admin.api_keys"
+	OpAdminImpersonation = "admin.impersonation"
+	OpAdminSessions      = "admin.sessions"
+	OpMetricsRead        = "metrics.read"
+)
+
+// KnownOperations 정의된 내부 API 작업 전체
+var KnownOperations = []string{
+	OpTokenCreate,
+	OpTokenValidate,
+	OpTokenExchange,
+	OpLoginAttempts,
+	OpAdminWebhooks,
+	OpAdminLockouts,
+	OpAdminAPIKeys,
+	OpAdminImpersonation,
+	OpAdminSessions,
+	OpMetricsRead,
+}
+
+// Caller 내부 API를 호출하는 서비스
+//
+// 자격 증명은 HMAC 시크릿, API 키, 클라이언트 인증서 식별자 중 하나 이상을 등록합니다.
+type Caller struct {
+	ID string `json:"id"`
+
+	// Operations 허용 작업 ("*" 또는 "admin.*" 같은 접두사 패턴 허용)
+	Operations []string `json:"operations"`
+
+	// HMACSecret 요청 서명용 공유 시크릿
+	HMACSecret string `json:"hmac_secret,omitempty"`
+
+	// APIKeySHA256 API 키의 SHA-256 해시 (hex, 키 원문은 보관하지 않음)
+	APIKeySHA256 string `json:"api_key_sha256,omitempty"`
+
+	// CertIdentity 클라이언트 인증서 식별자 (server.tls.client_identities 적용 후 값)
+	CertIdentity string `json:"cert_identity,omitempty"`
+
+	// SignaturePublicKey HTTP 메시지 서명(ed25519) 검증용 PKIX PEM 공개 키
+	// (없으면 hmac_secret으로 hmac-sha256 서명 검증)
+	SignaturePublicKey string `json:"signature_public_key,omitempty"`
+
+	// BindTokensToCertificate 이 호출자가 발급받는 토큰을 클라이언트 인증서에 바인딩 (RFC 8705, mTLS 필수)
+	BindTokensToCertificate bool `json:"bind_tokens_to_certificate,omitempty"`
+
+	// Audiences 이 호출자가 검증을 요청하면 통과시킬 교환 토큰의 aud (기본 audience 토큰은 항상 통과)
+	Audiences []string `json:"audiences,omitempty"`
+
+	publicKey ed25519.PublicKey
+}
+
+type contextKey struct{}
+
+// WithContext context에 인증된 호출자 추가
+func WithContext(ctx context.Context, c *Caller) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext context에서 인증된 호출자 조회 (내부 API가 아니면 nil)
+func FromContext(ctx context.Context) *Caller {
+	c, _ := ctx.Value(contextKey{}).(*Caller)
+	return c
+}
+
+// SignatureKey HTTP 메시지 서명 검증 키 (ed25519 공개 키 또는 HMAC 시크릿, 없으면 nil)
+func (c *Caller) SignatureKey() interface{} {
+	if c.publicKey != nil {
+		return c.publicKey
+	}
+	if c.HMACSecret != "" {
+		return []byte(c.HMACSecret)
+	}
+	return nil
+}
+
+// Allows 작업 허용 여부
+func (c *Caller) Allows(operation string) bool {
+	for _, pattern := range c.Operations {
+		if pattern == "*" || pattern == operation {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(operation, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// HashAPIKey API 키 해시 (호출자 목록의 api_key_sha256 값)
+//
+// API 키는 충분히 긴 무작위 값이므로 요청마다 계산할 수 있도록 느린 해시 대신 SHA-256을 사용합니다.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadCallers JSON 파일에서 호출자 목록 로드
+func LoadCallers(path string) ([]Caller, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("호출자 목록 파일 읽기 실패: %w", err)
+	}
+
+	var callers []Caller
+	if err := json.Unmarshal(data, &callers); err != nil {
+		return nil, fmt.Errorf("호출자 목록 파싱 실패: %w", err)
+	}
+
+	ids := make(map[string]bool, len(callers))
+	for i := range callers {
+		c := &callers[i]
+		if c.ID == "" {
+			return nil, fmt.Errorf("호출자에는 id가 필요합니다")
+		}
+		if ids[c.ID] {
+			return nil, fmt.Errorf("중복된 호출자 id: %s", c.ID)
+		}
+		if c.HMACSecret == "" && c.APIKeySHA256 == "" && c.CertIdentity == "" && c.SignaturePublicKey == "" {
+			return nil, fmt.Errorf("호출자 %s에는 hmac_secret, api_key_sha256, cert_identity, signature_public_key 중 하나 이상이 필요합니다", c.ID)
+		}
+		if c.SignaturePublicKey != "" {
+			key, err := parsePublicKey(c.SignaturePublicKey)
+			if err != nil {
+				return nil, fmt.Errorf("호출자 %s의 signature_public_key 파싱 실패: %w", c.ID, err)
+			}
+			c.publicKey = key
+		}
+		if c.APIKeySHA256 != "" {
+			if b, err := hex.DecodeString(c.APIKeySHA256); err != nil || len(b) != sha256.Size {
+				return nil, fmt.Errorf("호출자 %s의 api_key_sha256은 SHA-256 hex 값이어야 합니다", c.ID)
+			}
+		}
+		ids[c.ID] = true
+	}
+	return callers, nil
+}
+
+// parsePublicKey PKIX PEM 형식의 ed25519 공개 키 파싱
+func parsePublicKey(data string) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("PEM 블록이 없습니다")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("ed25519 공개 키가 아닙니다")
+	}
+	return publicKey, nil
+}
+
+// Registry 호출자 목록 (reload 시 통째로 교체)
+type Registry struct {
+	callers atomic.Pointer[[]Caller]
+}
+
+// NewRegistry 호출자 목록 생성자 (path가 비어 있으면 빈 목록)
+func NewRegistry(path string) (*Registry, error) {
+	r := &Registry{}
+	commit, err := r.Prepare(path)
+	if err != nil {
+		return nil, err
+	}
+	commit()
+	return r, nil
+}
+
+// Prepare 새 호출자 목록 파일을 읽고 교체 함수 반환 (reload 적용용)
+func (r *Registry) Prepare(path string) (func(), error) {
+	var callers []Caller
+	if path != "" {
+		var err error
+		if callers, err = LoadCallers(path); err != nil {
+			return nil, err
+		}
+	}
+	return func() { r.callers.Store(&callers) }, nil
+}
+
+// Empty 등록된 호출자가 없는지 여부
+func (r *Registry) Empty() bool {
+	return len(r.list()) == 0
+}
+
+// Lookup id로 호출자 조회
+func (r *Registry) Lookup(id string) (*Caller, bool) {
+	for _, c := range r.list() {
+		if c.ID == id {
+			return &c, true
+		}
+	}
+	return nil, false
+}
+
+// ByAPIKey API 키로 호출자 조회
+func (r *Registry) ByAPIKey(key string) (*Caller, bool) {
+	hash := []byte(HashAPIKey(key))
+	for _, c := range r.list() {
+		if c.APIKeySHA256 != "" && subtle.ConstantTimeCompare(hash, []byte(strings.ToLower(c.APIKeySHA256))) == 1 {
+			return &c, true
+		}
+	}
+	return nil, false
+}
+
+// ByCertIdentity 클라이언트 인증서 식별자로 호출자 조회
+func (r *Registry) ByCertIdentity(identity string) (*Caller, bool) {
+	for _, c := range r.list() {
+		if c.CertIdentity != "" && c.CertIdentity == identity {
+			return &c, true
+		}
+	}
+	return nil, false
+}
+
+func (r *Registry) list() []Caller {
+	if callers := r.callers.Load(); callers != nil {
+		return *callers
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package caller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/pkg/httpsig"
+)
+
+// NonceStore 서명 nonce 재사용 확인 (HTTP 메시지 서명과 HMAC 서명 공용)
+type NonceStore interface {
+	// Claim nonce를 처음 사용하면 true (ttl 동안 같은 nonce는 false)
+	Claim(ctx context.Context, keyID, nonce string, ttl time.Duration) (bool, error)
+}
+
+type redisNonceStore struct {
+	client *redis.Client
+}
+
+// NewRedisNonceStore SETNX 기반 nonce 저장소 생성자 (여러 인스턴스가 재전송 여부를 공유)
+func NewRedisNonceStore(client *redis.Client) NonceStore {
+	return &redisNonceStore{
+		client: client,
+	}
+}
+
+func (s *redisNonceStore) Claim(ctx context.Context, keyID, nonce string, ttl time.Duration) (bool, error) {
+	ok, err := s.client.SetNX(ctx, "httpsig:nonce:"+keyID+":"+nonce, 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("서명 nonce 기록 실패: %w", err)
+	}
+	return ok, nil
+}
+
+// MessageSignatureAuthenticator HTTP 메시지 서명(RFC 9421) 인증
+//
+// keyid는 호출자 ID이며, @method, @path, date, content-digest와 signedHeaders 중 요청에 있는
+// 헤더가 서명에 포함되어야 합니다. created와 Date가 허용 오차 안에 있어야 하고,
+// 같은 nonce는 허용 오차의 두 배 동안 다시 쓸 수 없으므로 캡처한 요청을 재전송할 수 없습니다.
+type MessageSignatureAuthenticator struct {
+	registry      *Registry
+	nonces        NonceStore
+	maxSkew       time.Duration
+	signedHeaders []string
+}
+
+// NewMessageSignatureAuthenticator HTTP 메시지 서명 인증 생성자
+func NewMessageSignatureAuthenticator(registry *Registry, nonces NonceStore, maxSkew time.Duration, signedHeaders []string) *MessageSignatureAuthenticator {
+	return &MessageSignatureAuthenticator{
+		registry:      registry,
+		nonces:        nonces,
+		maxSkew:       maxSkew,
+		signedHeaders: signedHeaders,
+	}
+}
+
+func (a *MessageSignatureAuthenticator) Authenticate(ctx context.Context, req *Request) (*Caller, bool, error) {
+	if req.Header.Get(httpsig.HeaderSignatureInput) == "" {
+		return nil, false, nil
+	}
+
+	u, err := url.ParseRequestURI(req.URI)
+	if err != nil {
+		return nil, true, domain.ErrAuthenticationFailed
+	}
+
+	var c *Caller
+	params, err := httpsig.Verify(&http.Request{
+		Method: req.Method,
+		URL:    u,
+		Host:   req.Host,
+		Header: req.Header,
+	}, req.Body, httpsig.VerifyOptions{
+		Key: func(keyID string) (interface{}, error) {
+			found, ok := a.registry.Lookup(keyID)
+			if !ok || found.SignatureKey() == nil {
+				return nil, fmt.Errorf("httpsig: unknown keyid %q", keyID)
+			}
+			c = found
+			return found.SignatureKey(), nil
+		},
+		RequiredIfPresent: a.signedHeaders,
+		MaxSkew:           a.maxSkew,
+	})
+	if err != nil {
+		slog.InfoContext(ctx, "HTTP 메시지 서명 검증 실패", slog.Any("error", err))
+		return nil, true, domain.ErrAuthenticationFailed
+	}
+
+	if params.Nonce == "" {
+		slog.InfoContext(ctx, "nonce 없는 HTTP 메시지 서명 거부", slog.String("keyid", params.KeyID))
+		return nil, true, domain.ErrAuthenticationFailed
+	}
+	fresh, err := a.nonces.Claim(ctx, params.KeyID, params.Nonce, 2*a.maxSkew)
+	if err != nil {
+		return nil, true, err
+	}
+	if !fresh {
+		slog.WarnContext(ctx, "재전송된 HTTP 메시지 서명 거부", slog.String("keyid", params.KeyID))
+		return nil, true, domain.ErrAuthenticationFailed
+	}
+	return c, true, nil
+}
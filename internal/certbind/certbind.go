@@ -0,0 +1,204 @@
+package certbind
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+
+	"github.com/signalable/qauth/internal/config"
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/proxy"
+)
+
+// HeaderForwardedClientCert 인증서 전달 헤더 기본 이름
+const HeaderForwardedClientCert = "X-Forwarded-Client-Cert"
+
+// state 한 시점의 인증서 전달 설정
+type state struct {
+	header  string
+	proxies proxy.Trusted
+}
+
+// Resolver 요청을 보낸 클라이언트의 인증서를 찾아 thumbprint를 context에 추가 (RFC 8705)
+//
+// qauth가 직접 TLS를 종료하면 검증된 연결 인증서를, 신뢰하는 프록시를 거치면 프록시가
+// 전달한 인증서 헤더를 사용합니다.
+type Resolver struct {
+	state atomic.Pointer[state]
+}
+
+// NewResolver 클라이언트 인증서 확인기 생성자
+func NewResolver(cfg config.CertBindingConfig) (*Resolver, error) {
+	r := &Resolver{}
+	commit, err := r.Prepare(cfg)
+	if err != nil {
+		return nil, err
+	}
+	commit()
+	return r, nil
+}
+
+// Prepare 새 설정 검증 후 적용 함수 반환 (hot reload용)
+func (r *Resolver) Prepare(cfg config.CertBindingConfig) (func(), error) {
+	proxies, err := proxy.ParseTrusted(cfg.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("신뢰 프록시 주소 파싱 실패: %w", err)
+	}
+	st := &state{header: cfg.ForwardedHeader, proxies: proxies}
+	return func() { r.state.Store(st) }, nil
+}
+
+// Handler 모든 요청에 클라이언트 인증서 thumbprint를 추가하는 미들웨어
+func (r *Resolver) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		req, err := r.Attach(req, false)
+		if err != nil {
+			// 형식이 잘못된 헤더는 인증서가 없는 것으로 취급 (바인딩된 토큰은 검증에 실패)
+			req = req.WithContext(domain.WithCertificateThumbprint(req.Context(), ""))
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// Attach 요청 클라이언트 인증서의 thumbprint를 context에 추가
+//
+// forwarded가 true면 인증된 내부 호출자의 요청이므로 인증서 헤더가 있으면 출처와 무관하게 그 값을 사용하고,
+// 없으면 연결 인증서를 유지합니다. 인증서 헤더 형식이 잘못되었으면 원래 요청과 함께 에러를 반환합니다.
+func (r *Resolver) Attach(req *http.Request, forwarded bool) (*http.Request, error) {
+	st := r.state.Load()
+
+	if value := headerValue(req, st.header); value != "" && (forwarded || st.proxies.Contains(req.RemoteAddr)) {
+		thumbprint, err := ParseForwarded(value)
+		if err != nil {
+			return req, fmt.Errorf("%w: %v", domain.ErrInvalidRequest, err)
+		}
+		return req.WithContext(domain.WithCertificateThumbprint(req.Context(), thumbprint)), nil
+	}
+	if forwarded {
+		return req, nil
+	}
+
+	thumbprint := ""
+	if req.TLS != nil && len(req.TLS.VerifiedChains) > 0 && len(req.TLS.VerifiedChains[0]) > 0 {
+		thumbprint = Thumbprint(req.TLS.VerifiedChains[0][0])
+	}
+	return req.WithContext(domain.WithCertificateThumbprint(req.Context(), thumbprint)), nil
+}
+
+func headerValue(req *http.Request, header string) string {
+	if header == "" {
+		return ""
+	}
+	return strings.TrimSpace(req.Header.Get(header))
+}
+
+// Thumbprint 인증서 DER의 SHA-256 thumbprint (base64url, cnf.x5t#S256 값)
+func Thumbprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// ParseForwarded 프록시가 전달한 인증서 헤더 값에서 thumbprint 추출
+//
+// Envoy x-forwarded-client-cert(Hash 또는 Cert, 여러 요소면 마지막 프록시가 추가한 요소),
+// URL 인코딩 PEM(nginx $ssl_client_escaped_cert, Envoy ext_authz source.certificate),
+// 헤더 줄 없는 base64 DER(Traefik)을 지원합니다.
+func ParseForwarded(value string) (string, error) {
+	if strings.Contains(value, "Hash=") || strings.Contains(value, "Cert=") {
+		return parseXFCC(value)
+	}
+
+	unescaped, err := url.PathUnescape(value)
+	if err != nil {
+		return "", fmt.Errorf("인증서 헤더 디코딩 실패: %w", err)
+	}
+	if strings.Contains(unescaped, "-----BEGIN") {
+		return pemThumbprint(unescaped)
+	}
+
+	// Traefik은 체인을 쉼표로 구분하며 첫 번째가 클라이언트 인증서
+	first, _, _ := strings.Cut(value, ",")
+	der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(first))
+	if err != nil {
+		return "", errors.New("지원하지 않는 인증서 헤더 형식입니다")
+	}
+	return derThumbprint(der)
+}
+
+// parseXFCC Envoy x-forwarded-client-cert 헤더의 마지막 요소에서 thumbprint 추출
+func parseXFCC(value string) (string, error) {
+	elements := splitQuoted(value, ',')
+	element := elements[len(elements)-1]
+
+	var hash, cert string
+	for _, pair := range splitQuoted(element, ';') {
+		key, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+		val = strings.Trim(val, `"`)
+		switch strings.ToLower(key) {
+		case "hash":
+			hash = val
+		case "cert":
+			cert = val
+		}
+	}
+
+	if cert != "" {
+		unescaped, err := url.PathUnescape(cert)
+		if err != nil {
+			return "", fmt.Errorf("XFCC Cert 디코딩 실패: %w", err)
+		}
+		return pemThumbprint(unescaped)
+	}
+	if hash != "" {
+		sum, err := hex.DecodeString(hash)
+		if err != nil || len(sum) != sha256.Size {
+			return "", errors.New("XFCC Hash는 SHA-256 hex 값이어야 합니다")
+		}
+		return base64.RawURLEncoding.EncodeToString(sum), nil
+	}
+	return "", errors.New("XFCC에 Hash나 Cert가 없습니다")
+}
+
+// splitQuoted 큰따옴표 안의 구분자는 무시하고 분리
+func splitQuoted(value string, sep rune) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for i, c := range value {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case c == sep && !quoted:
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, value[start:])
+}
+
+func pemThumbprint(data string) (string, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return "", errors.New("PEM 인증서가 아닙니다")
+	}
+	return derThumbprint(block.Bytes)
+}
+
+func derThumbprint(der []byte) (string, error) {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return "", fmt.Errorf("인증서 파싱 실패: %w", err)
+	}
+	return Thumbprint(cert), nil
+}
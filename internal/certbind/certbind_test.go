@@ -0,0 +1,152 @@
+package certbind
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCertificate 테스트용 자체 서명 인증서
+func testCertificate(t *testing.T, cn string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func pemString(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
+
+func TestParseForwarded(t *testing.T) {
+	client := testCertificate(t, "client")
+	proxy := testCertificate(t, "proxy")
+	want := Thumbprint(client)
+
+	sum := sha256.Sum256(client.Raw)
+	hash := hex.EncodeToString(sum[:])
+	escapedPEM := url.PathEscape(pemString(client))
+	der := base64.StdEncoding.EncodeToString(client.Raw)
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "XFCC Hash",
+			value: "By=spiffe://qauth;Hash=" + hash + ";Subject=\"CN=client\"",
+			want:  want,
+		},
+		{
+			name:  "XFCC Cert",
+			value: "By=spiffe://qauth;Cert=\"" + escapedPEM + "\";Subject=\"CN=client\"",
+			want:  want,
+		},
+		{
+			name:  "XFCC 여러 요소면 마지막 요소",
+			value: "Hash=" + strings.Repeat("0", 64) + ";Subject=\"CN=a,O=b\"," + "Hash=" + hash,
+			want:  want,
+		},
+		{
+			name:  "XFCC 따옴표 안의 구분자",
+			value: "Subject=\"CN=client;O=x,y\";Hash=" + hash,
+			want:  want,
+		},
+		{
+			name:  "URL 인코딩 PEM",
+			value: escapedPEM,
+			want:  want,
+		},
+		{
+			name:  "base64 DER",
+			value: der,
+			want:  want,
+		},
+		{
+			name:  "base64 DER 체인은 첫 번째 인증서",
+			value: der + "," + base64.StdEncoding.EncodeToString(proxy.Raw),
+			want:  want,
+		},
+		{
+			name:    "XFCC Hash 길이 오류",
+			value:   "Hash=abcd",
+			wantErr: true,
+		},
+		{
+			name:    "XFCC Hash hex 오류",
+			value:   "Hash=" + strings.Repeat("z", 64),
+			wantErr: true,
+		},
+		{
+			name:    "XFCC Cert가 PEM이 아님",
+			value:   "Cert=\"not-a-pem\"",
+			wantErr: true,
+		},
+		{
+			name:    "잘못된 URL 인코딩",
+			value:   "%zz",
+			wantErr: true,
+		},
+		{
+			name:    "PEM이 아닌 블록",
+			value:   url.PathEscape(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte{1}}))),
+			wantErr: true,
+		},
+		{
+			name:    "인증서가 아닌 DER",
+			value:   base64.StdEncoding.EncodeToString([]byte("garbage")),
+			wantErr: true,
+		},
+		{
+			name:    "지원하지 않는 형식",
+			value:   "not a certificate",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseForwarded(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("thumbprint = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
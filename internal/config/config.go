@@ -1,78 +1,420 @@
 package config
 
 import (
-	"os"
-	"strconv"
+	"errors"
+	"fmt"
 	"time"
+)
 
-	"github.com/joho/godotenv"
+// 실행 환경
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Redis    RedisConfig
-	JWT      JWTConfig
-	LogLevel string
+	// Environment development 또는 production (production은 안전하지 않은 기본값을 거부)
+	Environment   string              `yaml:"environment" toml:"environment"`
+	Server        ServerConfig        `yaml:"server" toml:"server"`
+	Redis         RedisConfig         `yaml:"redis" toml:"redis"`
+	JWT           JWTConfig           `yaml:"jwt" toml:"jwt"`
+	Tracing       TracingConfig       `yaml:"tracing" toml:"tracing"`
+	Audit         AuditConfig         `yaml:"audit" toml:"audit"`
+	Webhook       WebhookConfig       `yaml:"webhook" toml:"webhook"`
+	CORS          CORSConfig          `yaml:"cors" toml:"cors"`
+	RateLimit     RateLimitConfig     `yaml:"rate_limit" toml:"rate_limit"`
+	Lockout       LockoutConfig       `yaml:"lockout" toml:"lockout"`
+	InternalAuth  InternalAuthConfig  `yaml:"internal_auth" toml:"internal_auth"`
+	DPoP          DPoPConfig          `yaml:"dpop" toml:"dpop"`
+	CertBinding   CertBindingConfig   `yaml:"cert_binding" toml:"cert_binding"`
+	ForwardAuth   ForwardAuthConfig   `yaml:"forward_auth" toml:"forward_auth"`
+	TokenExchange TokenExchangeConfig `yaml:"token_exchange" toml:"token_exchange"`
+	Impersonation ImpersonationConfig `yaml:"impersonation" toml:"impersonation"`
+	LogLevel      string              `yaml:"log_level" toml:"log_level"`
+
+	// ConfigFile 실제로 읽은 설정 파일 경로 (파일 변경 감시용)
+	ConfigFile string `yaml:"-" toml:"-"`
 }
 
+// EnvFile 설정을 읽는 .env 파일 경로
+const EnvFile = ".env"
+
 type ServerConfig struct {
-	Host string
-	Port string
+	Host     string `yaml:"host" toml:"host"`
+	Port     string `yaml:"port" toml:"port"`
+	GRPCPort string `yaml:"grpc_port" toml:"grpc_port"`
+
+	// MetricsAddr /metrics 전용 내부 리스너 주소 (host:port, 비우면 기본 포트에서 metrics.read 호출자만 조회)
+	MetricsAddr string `yaml:"metrics_addr" toml:"metrics_addr"`
+
+	// DrainPeriod 종료 신호 후 readiness 실패 상태로 새 요청을 받는 시간 (로드밸런서 제외 대기)
+	DrainPeriod time.Duration `yaml:"drain_period" toml:"drain_period"`
+
+	// ShutdownTimeout 처리 중인 요청 완료를 기다리는 최대 시간
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+
+	TLS TLSConfig `yaml:"tls" toml:"tls"`
+}
+
+type TLSConfig struct {
+	// CertFile, KeyFile 설정 시 HTTP/gRPC 모두 TLS로 서비스
+	CertFile string `yaml:"cert_file" toml:"cert_file"`
+	KeyFile  string `yaml:"key_file" toml:"key_file"`
+
+	// ClientCAFile 설정 시 내부 API(토큰 발급/검증)에 이 CA가 발급한 클라이언트 인증서 요구
+	ClientCAFile string `yaml:"client_ca_file" toml:"client_ca_file"`
+
+	// ClientIdentities 클라이언트 인증서 subject 또는 CN별 감사 로그용 호출자 식별자 (없으면 CN 사용)
+	ClientIdentities map[string]string `yaml:"client_identities" toml:"client_identities"`
+}
+
+// Enabled TLS 사용 여부
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != ""
 }
 
 type RedisConfig struct {
-	Addr     string
-	Password string
-	DB       int
+	Addr     string `yaml:"addr" toml:"addr"`
+	Password string `yaml:"password" toml:"password"`
+	DB       int    `yaml:"db" toml:"db"`
 }
 
 type JWTConfig struct {
-	SecretKey        string
-	ExpirationPeriod time.Duration
+	SecretKey        string        `yaml:"secret_key" toml:"secret_key"`
+	ExpirationPeriod time.Duration `yaml:"expiration" toml:"expiration"`
+	PrivateKeyFile   string        `yaml:"private_key_file" toml:"private_key_file"`
+	KeyID            string        `yaml:"key_id" toml:"key_id"`
+	Issuer           string        `yaml:"issuer" toml:"issuer"`
+	Audience         string        `yaml:"audience" toml:"audience"`
 }
 
-// LoadConfig .env 파일에서 설정을 로드
-func LoadConfig() (*Config, error) {
-	// .env 파일 로드
-	if err := godotenv.Load(); err != nil {
-		return nil, err
-	}
+type TracingConfig struct {
+	// Exporter none, otlp, stdout 중 하나
+	Exporter    string  `yaml:"exporter" toml:"exporter"`
+	Endpoint    string  `yaml:"endpoint" toml:"endpoint"`
+	Insecure    bool    `yaml:"insecure" toml:"insecure"`
+	SampleRatio float64 `yaml:"sample_ratio" toml:"sample_ratio"`
+	ServiceName string  `yaml:"service_name" toml:"service_name"`
+}
 
-	// Redis DB 번호 파싱
-	redisDB, err := strconv.Atoi(getEnv("REDIS_DB", "0"))
-	if err != nil {
-		redisDB = 0
-	}
+type AuditConfig struct {
+	// Sinks stdout, file, redis 조합 (비어 있으면 감사 로그 비활성화)
+	Sinks          []string `yaml:"sinks" toml:"sinks"`
+	FilePath       string   `yaml:"file_path" toml:"file_path"`
+	FileMaxBytes   int64    `yaml:"file_max_bytes" toml:"file_max_bytes"`
+	FileMaxBackups int      `yaml:"file_max_backups" toml:"file_max_backups"`
+	RedisStream    string   `yaml:"redis_stream" toml:"redis_stream"`
+	RedisMaxLen    int64    `yaml:"redis_max_len" toml:"redis_max_len"`
+}
 
-	// JWT 만료 시간 파싱 (시간 단위)
-	jwtExpirationHours, err := strconv.Atoi(getEnv("JWT_EXPIRATION_HOURS", "24"))
-	if err != nil {
-		jwtExpirationHours = 24
-	}
+type WebhookConfig struct {
+	// EndpointsFile 엔드포인트 목록 JSON 파일 (비어 있으면 웹훅 비활성화)
+	EndpointsFile string        `yaml:"endpoints_file" toml:"endpoints_file"`
+	MaxAttempts   int           `yaml:"max_attempts" toml:"max_attempts"`
+	BackoffBase   time.Duration `yaml:"backoff_base" toml:"backoff_base"`
+	BackoffMax    time.Duration `yaml:"backoff_max" toml:"backoff_max"`
+	Timeout       time.Duration `yaml:"timeout" toml:"timeout"`
+	Workers       int           `yaml:"workers" toml:"workers"`
+	QueueSize     int           `yaml:"queue_size" toml:"queue_size"`
+	DeadLetterKey string        `yaml:"dead_letter_key" toml:"dead_letter_key"`
+}
+
+// CORSConfig 브라우저 교차 출처 요청 정책 (허용 출처가 없으면 CORS 헤더를 보내지 않음)
+//
+// 내부 서비스 간 API는 설정과 무관하게 브라우저 접근이 차단됩니다.
+type CORSConfig struct {
+	// AllowedOrigins 기본 정책의 허용 출처 (정확한 값, "https://*.example.com" 같은 패턴, 또는 "*")
+	AllowedOrigins   []string      `yaml:"allowed_origins" toml:"allowed_origins"`
+	AllowedMethods   []string      `yaml:"allowed_methods" toml:"allowed_methods"`
+	AllowedHeaders   []string      `yaml:"allowed_headers" toml:"allowed_headers"`
+	ExposedHeaders   []string      `yaml:"exposed_headers" toml:"exposed_headers"`
+	AllowCredentials bool          `yaml:"allow_credentials" toml:"allow_credentials"`
+	MaxAge           time.Duration `yaml:"max_age" toml:"max_age"`
+
+	// Routes 경로별 정책 (위에서부터 처음 일치하는 항목 적용, 없으면 기본 정책)
+	Routes []CORSRoute `yaml:"routes" toml:"routes"`
+}
+
+type CORSRoute struct {
+	// Path 정확한 경로 또는 "*"로 끝나는 접두사 (예: "/api/auth/token/refresh", "/.well-known/*")
+	Path             string   `yaml:"path" toml:"path"`
+	AllowedOrigins   []string `yaml:"allowed_origins" toml:"allowed_origins"`
+	AllowCredentials bool     `yaml:"allow_credentials" toml:"allow_credentials"`
+}
+
+// 요청 제한 키 종류
+const (
+	RateLimitKeyIP     = "ip"
+	RateLimitKeyUser   = "user"
+	RateLimitKeyClient = "client"
+	RateLimitKeyToken  = "token"
+)
+
+// RateLimitConfig Redis 기반 요청 제한 (여러 인스턴스가 같은 한도를 공유)
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled" toml:"enabled"`
+
+	// Routes 경로별 한도 (경로에 일치하는 항목을 모두 적용하므로 IP별, 토큰별 한도를 함께 걸 수 있음)
+	Routes []RateLimitRoute `yaml:"routes" toml:"routes"`
+}
+
+type RateLimitRoute struct {
+	// Path 정확한 경로 또는 "*"로 끝나는 접두사
+	Path string `yaml:"path" toml:"path"`
+
+	// Key 한도를 나누는 기준 (ip, user, client, token 중 하나, 값이 없는 요청은 ip로 대체)
+	Key string `yaml:"key" toml:"key"`
+
+	// Limit Period 동안 허용하는 요청 수
+	Limit  int           `yaml:"limit" toml:"limit"`
+	Period time.Duration `yaml:"period" toml:"period"`
+
+	// Burst 연속으로 허용하는 최대 요청 수 (0이면 Limit)
+	Burst int `yaml:"burst" toml:"burst"`
+}
+
+// LockoutConfig 로그인 실패 누적에 따른 지연 및 잠금
+type LockoutConfig struct {
+	// MaxFailures 계정을 잠그는 연속 실패 횟수
+	MaxFailures int `yaml:"max_failures" toml:"max_failures"`
+
+	// IPMaxFailures IP를 차단하는 실패 횟수 (여러 계정에 대한 실패 합산)
+	IPMaxFailures int `yaml:"ip_max_failures" toml:"ip_max_failures"`
+
+	// FailureWindow 마지막 실패 후 실패 횟수를 유지하는 시간
+	FailureWindow time.Duration `yaml:"failure_window" toml:"failure_window"`
+
+	// BaseDelay 계정 첫 실패 후 다음 시도까지 대기 시간 (실패할 때마다 두 배, MaxDelay까지)
+	BaseDelay time.Duration `yaml:"base_delay" toml:"base_delay"`
+	MaxDelay  time.Duration `yaml:"max_delay" toml:"max_delay"`
+
+	// LockDuration 잠금 유지 시간 (지나면 실패 횟수와 함께 자동 해제)
+	LockDuration time.Duration `yaml:"lock_duration" toml:"lock_duration"`
+}
+
+// InternalAuthConfig 내부 API(토큰 발급/검증, 관리 API) 호출자 인증
+type InternalAuthConfig struct {
+	// CallersFile 호출자와 허용 작업 목록 JSON 파일 (production 필수, development에서 비우면 인증 생략)
+	CallersFile string `yaml:"callers_file" toml:"callers_file"`
+
+	// SignatureTolerance 요청 서명 시각의 허용 오차 (HTTP 메시지 서명 nonce는 그 두 배 동안 보관)
+	SignatureTolerance time.Duration `yaml:"signature_tolerance" toml:"signature_tolerance"`
+
+	// SignedHeaders 요청에 있으면 HTTP 메시지 서명에 반드시 포함되어야 하는 헤더
+	SignedHeaders []string `yaml:"signed_headers" toml:"signed_headers"`
+}
+
+// DPoPConfig RFC 9449 DPoP 증명 검증
+type DPoPConfig struct {
+	// ProofMaxAge 증명 iat와 서버 시각의 허용 차이 (jti 재사용 기록은 그 두 배 동안 보관)
+	ProofMaxAge time.Duration `yaml:"proof_max_age" toml:"proof_max_age"`
+
+	// BaseURL qauth 외부 주소 (예: https://auth.example.com, 프록시 뒤에서 refresh/revoke 증명의 htu 비교용, gRPC는 이 주소 뒤에 RPC 메서드 경로)
+	// 비우면 요청의 Host와 TLS 여부로 주소를 구성합니다.
+	BaseURL string `yaml:"base_url" toml:"base_url"`
+}
+
+// CertBindingConfig 인증서 바인딩 토큰(RFC 8705)의 클라이언트 인증서 전달 설정
+type CertBindingConfig struct {
+	// ForwardedHeader TLS를 종료한 프록시나 내부 호출자가 클라이언트 인증서를 전달하는 헤더
+	// (Envoy XFCC, URL 인코딩 PEM, base64 DER 형식 지원)
+	ForwardedHeader string `yaml:"forwarded_header" toml:"forwarded_header"`
 
+	// TrustedProxies 이 주소(IP 또는 CIDR)에서 온 요청은 연결 인증서 대신 ForwardedHeader의 인증서 사용
+	// (내부 API는 인증된 호출자가 보낸 헤더를 항상 사용)
+	TrustedProxies []string `yaml:"trusted_proxies" toml:"trusted_proxies"`
+}
+
+// ForwardAuthConfig 프록시 위임 인증(forward auth, ext_authz) 설정
+type ForwardAuthConfig struct {
+	// TrustedProxies 이 주소(IP 또는 CIDR)에서 온 요청만 X-Forwarded-*의 원 요청 정보와 클라이언트 주소 사용
+	// (그 외에는 연결 주소와 qauth가 받은 요청 기준)
+	TrustedProxies []string `yaml:"trusted_proxies" toml:"trusted_proxies"`
+
+	// Audiences 원 요청 호스트/경로별로 통과시킬 교환 토큰의 aud (먼저 일치한 규칙 적용,
+	// 일치하는 규칙이 없으면 검증을 요청한 호출자의 audiences 기준, 기본 audience 토큰은 항상 통과)
+	Audiences []AudienceRoute `yaml:"audiences" toml:"audiences"`
+}
+
+// AudienceRoute 원 요청 호스트/경로에 허용하는 토큰 aud
+type AudienceRoute struct {
+	// Host 원 요청 호스트 (포트 제외, 비우면 모든 호스트)
+	Host string `yaml:"host" toml:"host"`
+
+	// PathPrefix 원 요청 경로 접두사 (경로 단위로 비교, 비우면 모든 경로)
+	PathPrefix string `yaml:"path_prefix" toml:"path_prefix"`
+
+	// Audiences 허용하는 aud (토큰 aud에 하나라도 있으면 통과)
+	Audiences []string `yaml:"audiences" toml:"audiences"`
+}
+
+// TokenExchangeConfig RFC 8693 토큰 교환 정책
+type TokenExchangeConfig struct {
+	// TokenTTL 교환 토큰 유효 시간 (subject 토큰이 먼저 만료되면 그때까지)
+	TokenTTL time.Duration `yaml:"token_ttl" toml:"token_ttl"`
+
+	// Audiences 교환 토큰을 발급할 수 있는 대상 서비스 (목록에 없는 audience는 거부)
+	Audiences []ExchangeAudience `yaml:"audiences" toml:"audiences"`
+}
+
+// ExchangeAudience 토큰 교환 대상 서비스와 허용 scope
+type ExchangeAudience struct {
+	Name string `yaml:"name" toml:"name"`
+
+	// Scopes 이 대상에 허용하는 최대 scope (요청 scope는 이 범위와 subject 토큰의 scope 안으로 축소)
+	Scopes []string `yaml:"scopes" toml:"scopes"`
+}
+
+// ImpersonationConfig 관리자 대리 로그인 정책
+type ImpersonationConfig struct {
+	// Scopes 대리 로그인 토큰에 허용하는 최대 scope (비어 있으면 대리 로그인 비활성화)
+	Scopes []string `yaml:"scopes" toml:"scopes"`
+
+	// DefaultTTL 요청에 유효 시간이 없을 때 적용할 유효 시간
+	DefaultTTL time.Duration `yaml:"default_ttl" toml:"default_ttl"`
+
+	// MaxTTL 요청할 수 있는 최대 유효 시간
+	MaxTTL time.Duration `yaml:"max_ttl" toml:"max_ttl"`
+}
+
+// IsProduction production 환경 여부
+func (c *Config) IsProduction() bool {
+	return c.Environment == EnvProduction
+}
+
+// defaults 기본 설정 (개발 환경 기준)
+func defaults() *Config {
 	return &Config{
+		Environment: EnvDevelopment,
 		Server: ServerConfig{
-			Host: getEnv("SERVER_HOST", "0.0.0.0"),
-			Port: getEnv("SERVER_PORT", "8080"),
+			Host:     "0.0.0.0",
+			Port:     "8080",
+			GRPCPort: "9090",
+
+			DrainPeriod:     5 * time.Second,
+			ShutdownTimeout: 15 * time.Second,
 		},
 		Redis: RedisConfig{
-			Addr:     getEnv("REDIS_ADDR", "localhost:6379"),
-			Password: getEnv("REDIS_PASSWORD", ""),
-			DB:       redisDB,
+			Addr: "localhost:6379",
 		},
 		JWT: JWTConfig{
-			SecretKey:        getEnv("JWT_SECRET_KEY", "your-secret-key"),
-			ExpirationPeriod: time.Duration(jwtExpirationHours) * time.Hour,
+			SecretKey:        defaultSecretKey,
+			ExpirationPeriod: 24 * time.Hour,
+			KeyID:            "qauth-1",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			Endpoint:    "localhost:4317",
+			Insecure:    true,
+			SampleRatio: 1.0,
+			ServiceName: "qauth",
+		},
+		Audit: AuditConfig{
+			Sinks:          []string{"stdout"},
+			FilePath:       "audit.log",
+			FileMaxBytes:   100 * 1024 * 1024,
+			FileMaxBackups: 5,
+			RedisStream:    "audit:events",
+			RedisMaxLen:    100000,
+		},
+		Webhook: WebhookConfig{
+			MaxAttempts:   6,
+			BackoffBase:   time.Second,
+			BackoffMax:    5 * time.Minute,
+			Timeout:       10 * time.Second,
+			Workers:       4,
+			QueueSize:     1000,
+			DeadLetterKey: "webhook:dead_letters",
+		},
+		CORS: CORSConfig{
+			AllowedMethods: []string{"GET", "POST"},
+			AllowedHeaders: []string{"Authorization", "Content-Type", "Accept-Language", "X-Request-ID", "DPoP"},
+			ExposedHeaders: []string{
+				"X-Request-ID", "WWW-Authenticate",
+				"Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy",
+			},
+			MaxAge: 10 * time.Minute,
 		},
-		LogLevel: getEnv("LOG_LEVEL", "debug"),
-	}, nil
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Routes: []RateLimitRoute{
+				{Path: "/api/auth/token/refresh", Key: RateLimitKeyIP, Limit: 30, Period: time.Minute},
+				{Path: "/api/auth/token/refresh", Key: RateLimitKeyToken, Limit: 5, Period: time.Minute},
+				{Path: "/api/auth/token/revoke", Key: RateLimitKeyIP, Limit: 30, Period: time.Minute},
+				{Path: "/api/auth/token/validate", Key: RateLimitKeyClient, Limit: 6000, Period: time.Minute, Burst: 500},
+			},
+		},
+		Lockout: LockoutConfig{
+			MaxFailures:   5,
+			IPMaxFailures: 50,
+			FailureWindow: 15 * time.Minute,
+			BaseDelay:     time.Second,
+			MaxDelay:      30 * time.Second,
+			LockDuration:  15 * time.Minute,
+		},
+		InternalAuth: InternalAuthConfig{
+			SignatureTolerance: 5 * time.Minute,
+			SignedHeaders:      []string{"authorization", "x-user-id"},
+		},
+		DPoP: DPoPConfig{
+			ProofMaxAge: time.Minute,
+		},
+		CertBinding: CertBindingConfig{
+			ForwardedHeader: "X-Forwarded-Client-Cert",
+		},
+		TokenExchange: TokenExchangeConfig{
+			TokenTTL: 5 * time.Minute,
+		},
+		Impersonation: ImpersonationConfig{
+			DefaultTTL: 15 * time.Minute,
+			MaxTTL:     time.Hour,
+		},
+		LogLevel: "debug",
+	}
 }
 
-// getEnv 환경 변수를 가져오거나 기본값 반환
-func getEnv(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
+// Load 기본값 < 설정 파일 < .env < 환경 변수 < 플래그 순서로 설정을 합친 뒤 검증
+//
+// 설정 파일은 -config 플래그 또는 QAUTH_CONFIG_FILE로 지정하며 YAML/TOML을 지원합니다.
+// .env 파일이 없어도 실패하지 않으며, 값 파싱 오류와 검증 오류는 한 번에 모아서 반환합니다.
+func Load(args []string) (*Config, error) {
+	flags, err := parseFlags(args)
+	if err != nil {
+		return nil, err
 	}
-	return value
+
+	env, err := newEnvSource(EnvFile)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := defaults()
+
+	// 설정 파일
+	path := flags.configFile
+	if path == "" {
+		path, _ = env.lookup("QAUTH_CONFIG_FILE")
+	}
+	if path != "" {
+		if err := loadFile(path, cfg); err != nil {
+			return nil, err
+		}
+		cfg.ConfigFile = path
+	}
+
+	// .env 및 환경 변수
+	env.apply(cfg)
+
+	// 플래그
+	flags.apply(cfg)
+
+	if err := errors.Join(append(env.errs, cfg.Validate()...)...); err != nil {
+		return nil, fmt.Errorf("설정 검증 실패:\n%w", err)
+	}
+	return cfg, nil
+}
+
+// LoadConfig 플래그 없이 설정 로드 (관리 CLI 등 자체 플래그를 쓰는 프로그램용)
+func LoadConfig() (*Config, error) {
+	return Load(nil)
 }
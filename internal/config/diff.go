@@ -0,0 +1,39 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Diff 두 설정에서 값이 다른 항목의 이름 목록 (예: "jwt.secret_key", "log_level")
+//
+// 값은 포함하지 않으므로 시크릿이 바뀌어도 로그나 감사 이벤트에 그대로 남겨도 안전합니다.
+func Diff(old, new *Config) []string {
+	var changed []string
+	diffStruct(reflect.ValueOf(old).Elem(), reflect.ValueOf(new).Elem(), "", &changed)
+	return changed
+}
+
+func diffStruct(old, new reflect.Value, prefix string, changed *[]string) {
+	for i := 0; i < old.NumField(); i++ {
+		field := old.Type().Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			diffStruct(old.Field(i), new.Field(i), name, changed)
+			continue
+		}
+		if !reflect.DeepEqual(old.Field(i).Interface(), new.Field(i).Interface()) {
+			*changed = append(*changed, name)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// envSource 환경 변수와 .env 파일 값 (프로세스 환경 변수가 우선)
+//
+// .env 값은 프로세스 환경에 주입하지 않으므로 설정을 다시 읽을 때도 파일 변경이 반영됩니다.
+type envSource struct {
+	dotenv map[string]string
+	errs   []error
+}
+
+// newEnvSource .env 파일을 읽어 환경 변수 소스 생성 (파일이 없으면 환경 변수만 사용)
+func newEnvSource(path string) (*envSource, error) {
+	dotenv, err := godotenv.Read(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		dotenv = map[string]string{}
+	} else if err != nil {
+		return nil, fmt.Errorf(".env 파일 파싱 실패: %w", err)
+	}
+	return &envSource{dotenv: dotenv}, nil
+}
+
+// lookup 환경 변수 조회 (설정되어 있으면 빈 값이어도 ok)
+func (e *envSource) lookup(key string) (string, bool) {
+	if value, ok := os.LookupEnv(key); ok {
+		return value, true
+	}
+	value, ok := e.dotenv[key]
+	return value, ok
+}
+
+// apply 설정된 환경 변수로 값 덮어쓰기
+func (e *envSource) apply(cfg *Config) {
+	e.string("QAUTH_ENV", &cfg.Environment)
+
+	e.string("SERVER_HOST", &cfg.Server.Host)
+	e.string("SERVER_PORT", &cfg.Server.Port)
+	// 빈 값이면 gRPC 서버 비활성화
+	e.string("GRPC_PORT", &cfg.Server.GRPCPort)
+	e.string("METRICS_ADDR", &cfg.Server.MetricsAddr)
+	e.duration("SHUTDOWN_DRAIN_PERIOD", &cfg.Server.DrainPeriod)
+	e.duration("SHUTDOWN_TIMEOUT", &cfg.Server.ShutdownTimeout)
+	e.string("TLS_CERT_FILE", &cfg.Server.TLS.CertFile)
+	e.string("TLS_KEY_FILE", &cfg.Server.TLS.KeyFile)
+	e.string("TLS_CLIENT_CA_FILE", &cfg.Server.TLS.ClientCAFile)
+
+	e.string("REDIS_ADDR", &cfg.Redis.Addr)
+	e.string("REDIS_PASSWORD", &cfg.Redis.Password)
+	e.int("REDIS_DB", &cfg.Redis.DB)
+
+	e.string("JWT_SECRET_KEY", &cfg.JWT.SecretKey)
+	var jwtExpirationHours int
+	if e.int("JWT_EXPIRATION_HOURS", &jwtExpirationHours) {
+		cfg.JWT.ExpirationPeriod = time.Duration(jwtExpirationHours) * time.Hour
+	}
+	e.string("JWT_PRIVATE_KEY_FILE", &cfg.JWT.PrivateKeyFile)
+	e.string("JWT_KEY_ID", &cfg.JWT.KeyID)
+	e.string("JWT_ISSUER", &cfg.JWT.Issuer)
+	e.string("JWT_AUDIENCE", &cfg.JWT.Audience)
+
+	e.string("OTEL_TRACES_EXPORTER", &cfg.Tracing.Exporter)
+	e.string("OTEL_EXPORTER_OTLP_ENDPOINT", &cfg.Tracing.Endpoint)
+	e.bool("OTEL_EXPORTER_OTLP_INSECURE", &cfg.Tracing.Insecure)
+	e.float("OTEL_TRACES_SAMPLER_ARG", &cfg.Tracing.SampleRatio)
+	e.string("OTEL_SERVICE_NAME", &cfg.Tracing.ServiceName)
+
+	e.list("AUDIT_SINKS", &cfg.Audit.Sinks)
+	e.string("AUDIT_FILE_PATH", &cfg.Audit.FilePath)
+	var auditFileMaxSizeMB int
+	if e.int("AUDIT_FILE_MAX_SIZE_MB", &auditFileMaxSizeMB) {
+		cfg.Audit.FileMaxBytes = int64(auditFileMaxSizeMB) * 1024 * 1024
+	}
+	e.int("AUDIT_FILE_MAX_BACKUPS", &cfg.Audit.FileMaxBackups)
+	e.string("AUDIT_REDIS_STREAM", &cfg.Audit.RedisStream)
+	e.int64("AUDIT_REDIS_MAXLEN", &cfg.Audit.RedisMaxLen)
+
+	e.string("WEBHOOK_ENDPOINTS_FILE", &cfg.Webhook.EndpointsFile)
+	e.int("WEBHOOK_MAX_ATTEMPTS", &cfg.Webhook.MaxAttempts)
+	e.duration("WEBHOOK_BACKOFF_BASE", &cfg.Webhook.BackoffBase)
+	e.duration("WEBHOOK_BACKOFF_MAX", &cfg.Webhook.BackoffMax)
+	e.duration("WEBHOOK_TIMEOUT", &cfg.Webhook.Timeout)
+	e.int("WEBHOOK_WORKERS", &cfg.Webhook.Workers)
+	e.int("WEBHOOK_QUEUE_SIZE", &cfg.Webhook.QueueSize)
+	e.string("WEBHOOK_DEAD_LETTER_KEY", &cfg.Webhook.DeadLetterKey)
+
+	e.list("CORS_ALLOWED_ORIGINS", &cfg.CORS.AllowedOrigins)
+	e.bool("CORS_ALLOW_CREDENTIALS", &cfg.CORS.AllowCredentials)
+	e.duration("CORS_MAX_AGE", &cfg.CORS.MaxAge)
+
+	e.bool("RATE_LIMIT_ENABLED", &cfg.RateLimit.Enabled)
+
+	e.int("LOCKOUT_MAX_FAILURES", &cfg.Lockout.MaxFailures)
+	e.int("LOCKOUT_IP_MAX_FAILURES", &cfg.Lockout.IPMaxFailures)
+	e.duration("LOCKOUT_FAILURE_WINDOW", &cfg.Lockout.FailureWindow)
+	e.duration("LOCKOUT_BASE_DELAY", &cfg.Lockout.BaseDelay)
+	e.duration("LOCKOUT_MAX_DELAY", &cfg.Lockout.MaxDelay)
+	e.duration("LOCKOUT_DURATION", &cfg.Lockout.LockDuration)
+
+	e.string("INTERNAL_AUTH_CALLERS_FILE", &cfg.InternalAuth.CallersFile)
+	e.duration("INTERNAL_AUTH_SIGNATURE_TOLERANCE", &cfg.InternalAuth.SignatureTolerance)
+	e.list("INTERNAL_AUTH_SIGNED_HEADERS", &cfg.InternalAuth.SignedHeaders)
+
+	e.duration("DPOP_PROOF_MAX_AGE", &cfg.DPoP.ProofMaxAge)
+	e.string("DPOP_BASE_URL", &cfg.DPoP.BaseURL)
+
+	e.string("CERT_BINDING_FORWARDED_HEADER", &cfg.CertBinding.ForwardedHeader)
+	e.list("CERT_BINDING_TRUSTED_PROXIES", &cfg.CertBinding.TrustedProxies)
+
+	e.list("FORWARD_AUTH_TRUSTED_PROXIES", &cfg.ForwardAuth.TrustedProxies)
+
+	e.duration("TOKEN_EXCHANGE_TOKEN_TTL", &cfg.TokenExchange.TokenTTL)
+
+	e.list("IMPERSONATION_SCOPES", &cfg.Impersonation.Scopes)
+	e.duration("IMPERSONATION_DEFAULT_TTL", &cfg.Impersonation.DefaultTTL)
+	e.duration("IMPERSONATION_MAX_TTL", &cfg.Impersonation.MaxTTL)
+
+	e.string("LOG_LEVEL", &cfg.LogLevel)
+}
+
+// string 문자열 값 (빈 값도 명시적인 설정으로 취급)
+func (e *envSource) string(key string, dst *string) {
+	if value, ok := e.lookup(key); ok {
+		*dst = strings.TrimSpace(value)
+	}
+}
+
+// list 쉼표로 구분된 목록 (빈 값이면 빈 목록)
+func (e *envSource) list(key string, dst *[]string) {
+	if value, ok := e.lookup(key); ok {
+		*dst = splitList(value)
+	}
+}
+
+// int 정수 값 (빈 값은 무시, 파싱 실패는 에러로 기록), 값을 읽었으면 true
+func (e *envSource) int(key string, dst *int) bool {
+	return e.parse(key, func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*dst = n
+		return nil
+	})
+}
+
+func (e *envSource) int64(key string, dst *int64) bool {
+	return e.parse(key, func(value string) error {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		*dst = n
+		return nil
+	})
+}
+
+func (e *envSource) float(key string, dst *float64) bool {
+	return e.parse(key, func(value string) error {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		*dst = f
+		return nil
+	})
+}
+
+func (e *envSource) bool(key string, dst *bool) bool {
+	return e.parse(key, func(value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*dst = b
+		return nil
+	})
+}
+
+// duration 기간 값 (예: 500ms, 10s, 5m)
+func (e *envSource) duration(key string, dst *time.Duration) bool {
+	return e.parse(key, func(value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*dst = d
+		return nil
+	})
+}
+
+// parse 값이 있으면 파싱 (실패하면 기존 값을 유지하고 에러 기록)
+func (e *envSource) parse(key string, set func(string) error) bool {
+	value, ok := e.lookup(key)
+	value = strings.TrimSpace(value)
+	if !ok || value == "" {
+		return false
+	}
+
+	if err := set(value); err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: 올바르지 않은 값 %q", key, value))
+		return false
+	}
+	return true
+}
+
+// splitList 쉼표로 구분된 값을 공백 제거 후 목록으로 변환
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// loadFile YAML/TOML 설정 파일을 기존 값 위에 덮어쓰기 (확장자로 형식 판단)
+//
+// 파일에 없는 키는 기본값을 유지하고, 알 수 없는 키는 오타를 잡기 위해 에러로 처리합니다.
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("설정 파일 읽기 실패: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("설정 파일 파싱 실패 (%s): %w", path, err)
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(data), cfg)
+		if err != nil {
+			return fmt.Errorf("설정 파일 파싱 실패 (%s): %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("설정 파일에 알 수 없는 키가 있습니다 (%s): %v", path, undecoded)
+		}
+	default:
+		return fmt.Errorf("지원하지 않는 설정 파일 형식: %s (yaml, yml, toml만 지원)", path)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package config
+
+import "testing"
+
+func TestExampleConfigLoads(t *testing.T) {
+	cfg := defaults()
+	if err := loadFile("../../config.example.yaml", cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	// 예제의 시크릿 자리표시자는 배포 시 환경 변수로 덮어씀
+	cfg.JWT.SecretKey = "example-test-secret-key-0123456789abcdef"
+	if errs := cfg.Validate(); len(errs) > 0 {
+		t.Fatalf("예제 설정 검증 실패: %v", errs)
+	}
+	if len(cfg.ForwardAuth.Audiences) == 0 {
+		t.Error("forward_auth.audiences가 로드되지 않았습니다")
+	}
+}
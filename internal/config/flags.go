@@ -0,0 +1,64 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+)
+
+// flagValues 명령행 플래그 (지정된 플래그만 다른 설정을 덮어씀)
+type flagValues struct {
+	set        map[string]bool
+	configFile string
+	env        string
+	host       string
+	port       string
+	grpcPort   string
+	redisAddr  string
+	logLevel   string
+}
+
+// parseFlags 서버 명령행 플래그 파싱
+func parseFlags(args []string) (*flagValues, error) {
+	f := &flagValues{set: map[string]bool{}}
+
+	fs := flag.NewFlagSet("qauth", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&f.configFile, "config", "", "설정 파일 경로 (YAML/TOML)")
+	fs.StringVar(&f.env, "env", "", "실행 환경 (development|production)")
+	fs.StringVar(&f.host, "host", "", "HTTP/gRPC 바인드 주소")
+	fs.StringVar(&f.port, "port", "", "HTTP 포트")
+	fs.StringVar(&f.grpcPort, "grpc-port", "", "gRPC 포트 (빈 값이면 비활성화)")
+	fs.StringVar(&f.redisAddr, "redis-addr", "", "Redis 주소")
+	fs.StringVar(&f.logLevel, "log-level", "", "로그 레벨 (debug|info|warn|error)")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("플래그 파싱 실패: %w", err)
+	}
+	fs.Visit(func(fl *flag.Flag) {
+		f.set[fl.Name] = true
+	})
+	return f, nil
+}
+
+// apply 지정된 플래그 값으로 덮어쓰기
+func (f *flagValues) apply(cfg *Config) {
+	if f.set["env"] {
+		cfg.Environment = f.env
+	}
+	if f.set["host"] {
+		cfg.Server.Host = f.host
+	}
+	if f.set["port"] {
+		cfg.Server.Port = f.port
+	}
+	if f.set["grpc-port"] {
+		cfg.Server.GRPCPort = f.grpcPort
+	}
+	if f.set["redis-addr"] {
+		cfg.Redis.Addr = f.redisAddr
+	}
+	if f.set["log-level"] {
+		cfg.LogLevel = f.logLevel
+	}
+}
@@ -0,0 +1,332 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultSecretKey 개발용 기본 JWT 시크릿
+const defaultSecretKey = "your-secret-key"
+
+// minProductionSecretLength production에서 요구하는 HS256 시크릿 최소 길이 (바이트, 256비트)
+const minProductionSecretLength = 32
+
+// insecureSecrets 예제/기본값으로 배포된 적 있는 시크릿
+var insecureSecrets = map[string]bool{
+	defaultSecretKey:                       true,
+	"your-secret-key-change-in-production": true,
+	"secret":                               true,
+	"changeme":                             true,
+}
+
+// Validate 설정 값 검증 (모든 문제를 모아서 반환)
+func (c *Config) Validate() []error {
+	var errs []error
+	fail := func(field, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+	}
+
+	if c.Environment != EnvDevelopment && c.Environment != EnvProduction {
+		fail("environment", "development 또는 production이어야 합니다 (현재 %q)", c.Environment)
+	}
+
+	// 서버
+	if !validPort(c.Server.Port) {
+		fail("server.port", "1~65535 사이의 포트여야 합니다 (현재 %q)", c.Server.Port)
+	}
+	if c.Server.GRPCPort != "" && !validPort(c.Server.GRPCPort) {
+		fail("server.grpc_port", "1~65535 사이의 포트이거나 비어 있어야 합니다 (현재 %q)", c.Server.GRPCPort)
+	}
+	if c.Server.GRPCPort != "" && c.Server.GRPCPort == c.Server.Port {
+		fail("server.grpc_port", "HTTP 포트와 같을 수 없습니다")
+	}
+	if c.Server.MetricsAddr != "" {
+		if _, port, err := net.SplitHostPort(c.Server.MetricsAddr); err != nil || !validPort(port) {
+			fail("server.metrics_addr", "host:port 형식이거나 비어 있어야 합니다 (현재 %q)", c.Server.MetricsAddr)
+		}
+	}
+	if c.Server.DrainPeriod < 0 {
+		fail("server.drain_period", "0 이상이어야 합니다")
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		fail("server.shutdown_timeout", "0보다 커야 합니다")
+	}
+	if (c.Server.TLS.CertFile == "") != (c.Server.TLS.KeyFile == "") {
+		fail("server.tls", "cert_file과 key_file은 함께 설정해야 합니다")
+	}
+	if c.Server.TLS.ClientCAFile != "" && !c.Server.TLS.Enabled() {
+		fail("server.tls.client_ca_file", "TLS(cert_file, key_file) 없이 사용할 수 없습니다")
+	}
+
+	// Redis
+	if c.Redis.Addr == "" {
+		fail("redis.addr", "필수 값입니다")
+	}
+	if c.Redis.DB < 0 {
+		fail("redis.db", "0 이상이어야 합니다")
+	}
+
+	// JWT
+	if c.JWT.ExpirationPeriod <= 0 {
+		fail("jwt.expiration", "0보다 커야 합니다")
+	}
+	if c.JWT.SecretKey == "" && c.JWT.PrivateKeyFile == "" {
+		fail("jwt.secret_key", "시크릿 또는 서명 키 파일 중 하나는 필요합니다")
+	}
+	if c.IsProduction() && c.JWT.SecretKey != "" {
+		// HS256 토큰은 시크릿만 알면 위조할 수 있으므로 production에서는 기본값/짧은 값을 거부
+		if insecureSecrets[c.JWT.SecretKey] {
+			fail("jwt.secret_key", "production에서 기본/예제 시크릿은 사용할 수 없습니다")
+		} else if len(c.JWT.SecretKey) < minProductionSecretLength {
+			fail("jwt.secret_key", "production에서는 %d바이트 이상이어야 합니다 (현재 %d바이트)", minProductionSecretLength, len(c.JWT.SecretKey))
+		}
+	}
+
+	// 트레이싱
+	switch c.Tracing.Exporter {
+	case "none", "otlp", "stdout":
+	default:
+		fail("tracing.exporter", "none, otlp, stdout 중 하나여야 합니다 (현재 %q)", c.Tracing.Exporter)
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		fail("tracing.sample_ratio", "0.0~1.0 사이여야 합니다 (현재 %g)", c.Tracing.SampleRatio)
+	}
+
+	// 감사 로그
+	for _, sink := range c.Audit.Sinks {
+		switch sink {
+		case "stdout", "redis":
+		case "file":
+			if c.Audit.FilePath == "" {
+				fail("audit.file_path", "file sink를 사용할 때는 필수입니다")
+			}
+			if c.Audit.FileMaxBytes <= 0 {
+				fail("audit.file_max_bytes", "0보다 커야 합니다")
+			}
+		default:
+			fail("audit.sinks", "stdout, file, redis 중 하나여야 합니다 (현재 %q)", sink)
+		}
+	}
+	if c.Audit.FileMaxBackups < 0 {
+		fail("audit.file_max_backups", "0 이상이어야 합니다")
+	}
+
+	// 웹훅
+	if c.Webhook.EndpointsFile != "" {
+		if c.Webhook.MaxAttempts < 1 {
+			fail("webhook.max_attempts", "1 이상이어야 합니다")
+		}
+		if c.Webhook.BackoffBase <= 0 {
+			fail("webhook.backoff_base", "0보다 커야 합니다")
+		}
+		if c.Webhook.Timeout <= 0 {
+			fail("webhook.timeout", "0보다 커야 합니다")
+		}
+		if c.Webhook.Workers < 1 {
+			fail("webhook.workers", "1 이상이어야 합니다")
+		}
+		if c.Webhook.QueueSize < 0 {
+			fail("webhook.queue_size", "0 이상이어야 합니다")
+		}
+	}
+
+	// CORS
+	validateOrigins(fail, "cors.allowed_origins", c.CORS.AllowedOrigins, c.CORS.AllowCredentials)
+	if c.CORS.MaxAge < 0 {
+		fail("cors.max_age", "0 이상이어야 합니다")
+	}
+	for i, route := range c.CORS.Routes {
+		field := fmt.Sprintf("cors.routes[%d]", i)
+		if !strings.HasPrefix(route.Path, "/") {
+			fail(field+".path", "/로 시작해야 합니다 (현재 %q)", route.Path)
+		}
+		validateOrigins(fail, field+".allowed_origins", route.AllowedOrigins, route.AllowCredentials)
+	}
+
+	// 요청 제한
+	for i, route := range c.RateLimit.Routes {
+		field := fmt.Sprintf("rate_limit.routes[%d]", i)
+		if !strings.HasPrefix(route.Path, "/") {
+			fail(field+".path", "/로 시작해야 합니다 (현재 %q)", route.Path)
+		}
+		switch route.Key {
+		case RateLimitKeyIP, RateLimitKeyUser, RateLimitKeyClient, RateLimitKeyToken:
+		default:
+			fail(field+".key", "ip, user, client, token 중 하나여야 합니다 (현재 %q)", route.Key)
+		}
+		if route.Limit < 1 {
+			fail(field+".limit", "1 이상이어야 합니다")
+		}
+		if route.Period < time.Second {
+			fail(field+".period", "1초 이상이어야 합니다")
+		}
+		if route.Burst < 0 {
+			fail(field+".burst", "0 이상이어야 합니다")
+		}
+	}
+
+	// 계정 잠금
+	if c.Lockout.MaxFailures < 1 {
+		fail("lockout.max_failures", "1 이상이어야 합니다")
+	}
+	if c.Lockout.IPMaxFailures < 1 {
+		fail("lockout.ip_max_failures", "1 이상이어야 합니다")
+	}
+	if c.Lockout.FailureWindow < time.Second {
+		fail("lockout.failure_window", "1초 이상이어야 합니다")
+	}
+	if c.Lockout.BaseDelay < 0 {
+		fail("lockout.base_delay", "0 이상이어야 합니다")
+	}
+	if c.Lockout.MaxDelay < c.Lockout.BaseDelay {
+		fail("lockout.max_delay", "base_delay 이상이어야 합니다")
+	}
+	if c.Lockout.LockDuration < time.Second {
+		fail("lockout.lock_duration", "1초 이상이어야 합니다")
+	}
+
+	// 내부 API 호출자 인증
+	if c.IsProduction() && c.InternalAuth.CallersFile == "" {
+		fail("internal_auth.callers_file", "production에서는 내부 API 호출자 목록이 필요합니다")
+	}
+	if c.InternalAuth.SignatureTolerance <= 0 {
+		fail("internal_auth.signature_tolerance", "0보다 커야 합니다")
+	}
+
+	// DPoP
+	if c.DPoP.ProofMaxAge < time.Second {
+		fail("dpop.proof_max_age", "1초 이상이어야 합니다")
+	}
+	if c.DPoP.BaseURL != "" {
+		if u, err := url.Parse(c.DPoP.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			fail("dpop.base_url", "scheme과 host를 포함한 주소여야 합니다 (현재 %q)", c.DPoP.BaseURL)
+		}
+	}
+
+	// 인증서 바인딩
+	if c.CertBinding.ForwardedHeader == "" && len(c.CertBinding.TrustedProxies) > 0 {
+		fail("cert_binding.forwarded_header", "trusted_proxies를 설정하면 인증서 전달 헤더가 필요합니다")
+	}
+	for i, proxy := range c.CertBinding.TrustedProxies {
+		if _, err := ParseNetwork(proxy); err != nil {
+			fail(fmt.Sprintf("cert_binding.trusted_proxies[%d]", i), "IP 또는 CIDR이어야 합니다 (현재 %q)", proxy)
+		}
+	}
+
+	// 프록시 위임 인증
+	for i, proxy := range c.ForwardAuth.TrustedProxies {
+		if _, err := ParseNetwork(proxy); err != nil {
+			fail(fmt.Sprintf("forward_auth.trusted_proxies[%d]", i), "IP 또는 CIDR이어야 합니다 (현재 %q)", proxy)
+		}
+	}
+	for i, route := range c.ForwardAuth.Audiences {
+		field := fmt.Sprintf("forward_auth.audiences[%d]", i)
+		if strings.Contains(route.Host, ":") || strings.Contains(route.Host, "/") {
+			fail(field+".host", "포트나 경로 없는 호스트여야 합니다 (현재 %q)", route.Host)
+		}
+		if route.PathPrefix != "" && !strings.HasPrefix(route.PathPrefix, "/") {
+			fail(field+".path_prefix", "/로 시작해야 합니다 (현재 %q)", route.PathPrefix)
+		}
+		if len(route.Audiences) == 0 {
+			fail(field+".audiences", "허용 aud가 하나 이상 필요합니다")
+		}
+		for _, audience := range route.Audiences {
+			if audience == "" {
+				fail(field+".audiences", "비어 있을 수 없습니다")
+			}
+		}
+	}
+
+	// 토큰 교환
+	if c.TokenExchange.TokenTTL < time.Second || c.TokenExchange.TokenTTL > 24*time.Hour {
+		fail("token_exchange.token_ttl", "1초 이상 24시간 이하여야 합니다 (현재 %s)", c.TokenExchange.TokenTTL)
+	}
+	audiences := make(map[string]bool, len(c.TokenExchange.Audiences))
+	for i, audience := range c.TokenExchange.Audiences {
+		field := fmt.Sprintf("token_exchange.audiences[%d]", i)
+		if audience.Name == "" {
+			fail(field+".name", "비어 있을 수 없습니다")
+		} else if audiences[audience.Name] {
+			fail(field+".name", "중복된 대상입니다 (%q)", audience.Name)
+		}
+		audiences[audience.Name] = true
+		if len(audience.Scopes) == 0 {
+			fail(field+".scopes", "허용 scope가 하나 이상 필요합니다")
+		}
+		for _, scope := range audience.Scopes {
+			if scope == "" || strings.ContainsAny(scope, " \t") {
+				fail(field+".scopes", "공백 없는 값이어야 합니다 (현재 %q)", scope)
+			}
+		}
+	}
+
+	// 관리자 대리 로그인 (감사 기록 없이는 발급하지 않음)
+	if c.Impersonation.MaxTTL < time.Minute || c.Impersonation.MaxTTL > 8*time.Hour {
+		fail("impersonation.max_ttl", "1분 이상 8시간 이하여야 합니다 (현재 %s)", c.Impersonation.MaxTTL)
+	}
+	if c.Impersonation.DefaultTTL < time.Minute || c.Impersonation.DefaultTTL > c.Impersonation.MaxTTL {
+		fail("impersonation.default_ttl", "1분 이상 max_ttl 이하여야 합니다 (현재 %s)", c.Impersonation.DefaultTTL)
+	}
+	for _, scope := range c.Impersonation.Scopes {
+		if scope == "" || strings.ContainsAny(scope, " \t") {
+			fail("impersonation.scopes", "공백 없는 값이어야 합니다 (현재 %q)", scope)
+		}
+	}
+	if len(c.Impersonation.Scopes) > 0 && len(c.Audit.Sinks) == 0 {
+		fail("impersonation.scopes", "대리 로그인을 사용하려면 audit.sinks를 설정해야 합니다")
+	}
+
+	switch strings.ToLower(c.LogLevel) {
+	case "debug", "info", "warn", "warning", "error":
+	default:
+		fail("log_level", "debug, info, warn, error 중 하나여야 합니다 (현재 %q)", c.LogLevel)
+	}
+
+	return errs
+}
+
+// validateOrigins 허용 출처 패턴 검증 (credentials와 "*"는 함께 쓸 수 없음)
+func validateOrigins(fail func(field, format string, args ...interface{}), field string, origins []string, credentials bool) {
+	for _, origin := range origins {
+		if origin == "*" {
+			if credentials {
+				fail(field, "allow_credentials와 \"*\"는 함께 사용할 수 없습니다")
+			}
+			continue
+		}
+		if !strings.Contains(origin, "://") {
+			fail(field, "scheme을 포함한 출처여야 합니다 (현재 %q)", origin)
+			continue
+		}
+		if _, err := path.Match(origin, ""); err != nil {
+			fail(field, "올바르지 않은 패턴입니다 (현재 %q)", origin)
+		}
+	}
+}
+
+// ParseNetwork IP 또는 CIDR 파싱 (IP는 단일 주소 대역으로 변환)
+func ParseNetwork(value string) (*net.IPNet, error) {
+	if strings.Contains(value, "/") {
+		_, network, err := net.ParseCIDR(value)
+		return network, err
+	}
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address: %q", value)
+	}
+	bits := 8 * net.IPv6len
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, 8*net.IPv4len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
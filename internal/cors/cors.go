@@ -0,0 +1,218 @@
+package cors
+
+import (
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/signalable/qauth/internal/config"
+	"github.com/signalable/qauth/internal/delivery/http/response"
+	"github.com/signalable/qauth/internal/domain"
+)
+
+// policy 경로 하나에 적용되는 CORS 정책
+type policy struct {
+	origins     []string
+	credentials bool
+}
+
+// route 경로 패턴별 정책
+type route struct {
+	path   string
+	policy *policy
+}
+
+// rules 한 시점의 전체 정책 (reload 시 통째로 교체)
+type rules struct {
+	base           policy
+	routes         []route
+	methods        map[string]bool
+	allowMethods   string
+	headers        map[string]bool
+	allowHeaders   string
+	exposedHeaders string
+	maxAge         string
+}
+
+// CORS 경로별 출처 허용 목록을 적용하는 핸들러
+//
+// gorilla/mux는 메서드가 맞지 않는 요청(preflight OPTIONS 등)에는 미들웨어를 실행하지 않으므로
+// 라우터 미들웨어가 아니라 라우터 전체를 감싸서 사용합니다.
+type CORS struct {
+	rules    atomic.Pointer[rules]
+	internal []string
+}
+
+// New CORS 핸들러 생성자 (internal 경로는 정책과 무관하게 브라우저 접근 차단)
+func New(cfg config.CORSConfig, internal []string) *CORS {
+	c := &CORS{internal: internal}
+	c.rules.Store(compile(cfg))
+	return c
+}
+
+// Prepare 새 정책으로 교체하는 함수 반환 (reload 적용용, 설정은 이미 검증된 상태)
+func (c *CORS) Prepare(cfg config.CORSConfig) (func(), error) {
+	r := compile(cfg)
+	return func() { c.rules.Store(r) }, nil
+}
+
+// Handler CORS 정책을 적용하는 http.Handler
+func (c *CORS) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// 내부 API는 브라우저에서 호출할 이유가 없으므로 출처가 붙은 요청은 모두 거부
+		if matchAny(c.internal, r.URL.Path) {
+			response.Error(w, r, domain.ErrOriginNotAllowed)
+			return
+		}
+
+		rules := c.rules.Load()
+		p := rules.policy(r.URL.Path)
+		w.Header().Add("Vary", "Origin")
+
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+		if preflight {
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			if !p.allows(origin) || !rules.allowsPreflight(r) {
+				response.Error(w, r, domain.ErrOriginNotAllowed)
+				return
+			}
+
+			rules.setOrigin(w, p, origin)
+			w.Header().Set("Access-Control-Allow-Methods", rules.allowMethods)
+			if rules.allowHeaders != "" {
+				w.Header().Set("Access-Control-Allow-Headers", rules.allowHeaders)
+			}
+			if rules.maxAge != "" {
+				w.Header().Set("Access-Control-Max-Age", rules.maxAge)
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		// 허용되지 않은 출처는 CORS 헤더 없이 처리 (브라우저가 응답 읽기를 차단)
+		if p.allows(origin) {
+			rules.setOrigin(w, p, origin)
+			if rules.exposedHeaders != "" {
+				w.Header().Set("Access-Control-Expose-Headers", rules.exposedHeaders)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func compile(cfg config.CORSConfig) *rules {
+	r := &rules{
+		base: policy{
+			origins:     cfg.AllowedOrigins,
+			credentials: cfg.AllowCredentials,
+		},
+		methods:        map[string]bool{},
+		headers:        map[string]bool{},
+		allowMethods:   strings.Join(cfg.AllowedMethods, ", "),
+		allowHeaders:   strings.Join(cfg.AllowedHeaders, ", "),
+		exposedHeaders: strings.Join(cfg.ExposedHeaders, ", "),
+	}
+	for _, method := range cfg.AllowedMethods {
+		r.methods[strings.ToUpper(method)] = true
+	}
+	for _, header := range cfg.AllowedHeaders {
+		r.headers[strings.ToLower(header)] = true
+	}
+	if cfg.MaxAge > 0 {
+		r.maxAge = strconv.Itoa(int(cfg.MaxAge.Seconds()))
+	}
+	for _, rt := range cfg.Routes {
+		r.routes = append(r.routes, route{
+			path: rt.Path,
+			policy: &policy{
+				origins:     rt.AllowedOrigins,
+				credentials: rt.AllowCredentials,
+			},
+		})
+	}
+	return r
+}
+
+// policy 경로에 적용할 정책 (처음 일치하는 경로별 정책, 없으면 기본 정책)
+func (r *rules) policy(requestPath string) *policy {
+	for _, rt := range r.routes {
+		if matchPath(rt.path, requestPath) {
+			return rt.policy
+		}
+	}
+	return &r.base
+}
+
+// allowsPreflight preflight가 요청한 메서드와 헤더가 모두 허용되는지 여부
+func (r *rules) allowsPreflight(req *http.Request) bool {
+	if !r.methods[strings.ToUpper(req.Header.Get("Access-Control-Request-Method"))] {
+		return false
+	}
+	for _, header := range strings.Split(req.Header.Get("Access-Control-Request-Headers"), ",") {
+		header = strings.ToLower(strings.TrimSpace(header))
+		if header != "" && !r.headers[header] {
+			return false
+		}
+	}
+	return true
+}
+
+// setOrigin 허용 출처 헤더 작성 (credentials를 쓰면 "*" 대신 요청 출처를 그대로 반환)
+func (r *rules) setOrigin(w http.ResponseWriter, p *policy, origin string) {
+	if !p.credentials && contains(p.origins, "*") {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+	}
+	if p.credentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// allows 출처 허용 여부 ("https://*.example.com" 패턴의 *는 /를 제외한 임의 문자열)
+func (p *policy) allows(origin string) bool {
+	for _, allowed := range p.origins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+		if ok, _ := path.Match(allowed, origin); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPath 정확한 경로 또는 "*"로 끝나는 접두사 일치 여부
+func matchPath(pattern, requestPath string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(requestPath, prefix)
+	}
+	return pattern == requestPath
+}
+
+func matchAny(patterns []string, requestPath string) bool {
+	for _, pattern := range patterns {
+		if matchPath(pattern, requestPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
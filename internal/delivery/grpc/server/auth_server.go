@@ -0,0 +1,220 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/dpop"
+	"github.com/signalable/qauth/internal/usecase"
+	qauthv1 "github.com/signalable/qauth/pkg/proto/qauth/v1"
+)
+
+type AuthServer struct {
+	qauthv1.UnimplementedAuthServiceServer
+	authUseCase usecase.AuthUseCase
+}
+
+// NewAuthServer Auth gRPC 서버 생성자
+func NewAuthServer(authUseCase usecase.AuthUseCase) *AuthServer {
+	return &AuthServer{
+		authUseCase: authUseCase,
+	}
+}
+
+// CreateToken 토큰 생성
+func (s *AuthServer) CreateToken(ctx context.Context, req *qauthv1.CreateTokenRequest) (*qauthv1.AuthResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "사용자 ID가 필요합니다")
+	}
+
+	resp, err := s.authUseCase.CreateToken(ctx, req.GetUserId())
+	if err != nil {
+		return nil, toStatus(err, codes.Internal)
+	}
+
+	return toAuthResponse(resp), nil
+}
+
+// ValidateToken 토큰 검증
+func (s *AuthServer) ValidateToken(ctx context.Context, req *qauthv1.ValidateTokenRequest) (*qauthv1.ValidateTokenResponse, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "토큰이 필요합니다")
+	}
+
+	resp, err := s.authUseCase.ValidateToken(ctx, req.GetToken())
+	if err != nil {
+		return nil, toStatus(err, codes.Unauthenticated)
+	}
+
+	return &qauthv1.ValidateTokenResponse{
+		Valid:         resp.Valid,
+		UserId:        resp.UserID,
+		Scopes:        resp.Scopes,
+		Audience:      resp.Audience,
+		Actor:         toActor(resp.Actor),
+		Impersonation: resp.Impersonation,
+		ApiKeyId:      resp.APIKeyID,
+	}, nil
+}
+
+// RevokeToken 토큰 폐기
+//
+// HTTP 폐기 경로처럼 토큰을 먼저 검증하므로 키에 바인딩된 토큰은 dpop 메타데이터의 증명이나
+// 같은 클라이언트 인증서로 소유를 증명해야 폐기할 수 있습니다.
+func (s *AuthServer) RevokeToken(ctx context.Context, req *qauthv1.RevokeTokenRequest) (*qauthv1.RevokeTokenResponse, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "토큰이 필요합니다")
+	}
+
+	ctx, err := withProof(ctx)
+	if err != nil {
+		return nil, toStatus(err, codes.Unauthenticated)
+	}
+	if _, err := s.authUseCase.ValidateToken(ctx, req.GetToken()); err != nil {
+		return nil, toStatus(err, codes.Unauthenticated)
+	}
+
+	if err := s.authUseCase.RevokeToken(ctx, req.GetToken()); err != nil {
+		return nil, toStatus(err, codes.Unauthenticated)
+	}
+
+	return &qauthv1.RevokeTokenResponse{}, nil
+}
+
+// RevokeAllTokens 사용자의 모든 토큰 폐기
+func (s *AuthServer) RevokeAllTokens(ctx context.Context, req *qauthv1.RevokeAllTokensRequest) (*qauthv1.RevokeAllTokensResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "사용자 ID가 필요합니다")
+	}
+
+	if err := s.authUseCase.RevokeAllTokens(ctx, req.GetUserId()); err != nil {
+		return nil, toStatus(err, codes.Internal)
+	}
+
+	return &qauthv1.RevokeAllTokensResponse{}, nil
+}
+
+// RefreshToken 토큰 새로고침
+func (s *AuthServer) RefreshToken(ctx context.Context, req *qauthv1.RefreshTokenRequest) (*qauthv1.AuthResponse, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "토큰이 필요합니다")
+	}
+
+	ctx, err := withProof(ctx)
+	if err != nil {
+		return nil, toStatus(err, codes.Unauthenticated)
+	}
+
+	resp, err := s.authUseCase.RefreshToken(ctx, req.GetToken())
+	if err != nil {
+		return nil, toStatus(err, codes.Unauthenticated)
+	}
+
+	return toAuthResponse(resp), nil
+}
+
+// GetTokenMetadata 토큰 메타데이터 조회
+func (s *AuthServer) GetTokenMetadata(ctx context.Context, req *qauthv1.GetTokenMetadataRequest) (*qauthv1.TokenMetadata, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "토큰이 필요합니다")
+	}
+
+	metadata, err := s.authUseCase.GetTokenMetadata(ctx, req.GetToken())
+	if err != nil {
+		return nil, toStatus(err, codes.Unauthenticated)
+	}
+
+	return &qauthv1.TokenMetadata{
+		UserId:    metadata.UserID,
+		IssuedAt:  metadata.IssuedAt,
+		ExpiresAt: metadata.ExpiresAt,
+	}, nil
+}
+
+func toAuthResponse(resp *domain.AuthResponse) *qauthv1.AuthResponse {
+	return &qauthv1.AuthResponse{
+		AccessToken: resp.AccessToken,
+		TokenType:   resp.TokenType,
+		ExpiresIn:   resp.ExpiresIn,
+	}
+}
+
+// withProof dpop 메타데이터의 DPoP 증명을 context에 추가 (없으면 그대로)
+//
+// gRPC 호출은 HTTP/2 POST 요청이므로 증명의 htm은 POST, htu는 :authority와 RPC 전체 메서드 이름으로 구성한 주소입니다.
+// 증명이 여러 개거나 비어 있으면 ErrInvalidDPoPProof를 반환합니다.
+func withProof(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	proofs := md.Get(strings.ToLower(dpop.Header))
+	if len(proofs) == 0 {
+		return ctx, nil
+	}
+	if len(proofs) != 1 || proofs[0] == "" {
+		return ctx, domain.ErrInvalidDPoPProof
+	}
+
+	scheme := "http"
+	if p, ok := peer.FromContext(ctx); ok {
+		if _, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			scheme = "https"
+		}
+	}
+	var authority string
+	if values := md.Get(":authority"); len(values) > 0 {
+		authority = values[0]
+	}
+	method, _ := grpc.Method(ctx)
+
+	return domain.WithProof(ctx, domain.ProofRequest{
+		Proof:  proofs[0],
+		Method: http.MethodPost,
+		URL:    scheme + "://" + authority + method,
+	}), nil
+}
+
+// toActor 위임 체인을 중첩된 Actor 메시지로 변환 (위임 토큰이 아니면 nil)
+func toActor(actor *domain.Actor) *qauthv1.Actor {
+	if actor == nil {
+		return nil
+	}
+	return &qauthv1.Actor{
+		Sub: actor.Subject,
+		Act: toActor(actor.Actor),
+	}
+}
+
+// toStatus domain 에러를 gRPC 상태 코드로 변환
+//
+// 토큰 검증 계열 메서드는 JWT 파싱 에러처럼 domain 에러로 감싸지지 않은 실패도
+// 인증 실패로 취급하므로 fallback을 codes.Unauthenticated로 지정합니다.
+func toStatus(err error, fallback codes.Code) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidToken),
+		errors.Is(err, domain.ErrExpiredToken),
+		errors.Is(err, domain.ErrRevokedToken),
+		errors.Is(err, domain.ErrInvalidDPoPProof),
+		errors.Is(err, domain.ErrAuthenticationFailed),
+		errors.Is(err, domain.ErrInvalidCredentials):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, domain.ErrUnauthorized):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case fallback == codes.Unauthenticated:
+		return status.Error(codes.Unauthenticated, domain.ErrInvalidToken.Error())
+	default:
+		return status.Error(fallback, "요청 처리에 실패했습니다")
+	}
+}
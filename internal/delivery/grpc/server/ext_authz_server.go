@@ -0,0 +1,158 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+
+	"github.com/signalable/qauth/internal/apikey"
+	"github.com/signalable/qauth/internal/certbind"
+	"github.com/signalable/qauth/internal/config"
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/dpop"
+	"github.com/signalable/qauth/internal/proxy"
+	"github.com/signalable/qauth/internal/usecase"
+)
+
+// 업스트림으로 전달되는 식별 헤더
+const (
+	headerUserID = "x-user-id"
+	headerScopes = "x-scopes"
+
+	// headerImpersonatedBy 관리자 대리 로그인 토큰이면 대리 로그인한 관리자 ID (아니면 빈 값)
+	headerImpersonatedBy = "x-impersonated-by"
+)
+
+type ExtAuthzServer struct {
+	authv3.UnimplementedAuthorizationServer
+	authUseCase usecase.AuthUseCase
+	audiences   atomic.Pointer[proxy.Audiences]
+}
+
+// NewExtAuthzServer Envoy ext_authz gRPC 서버 생성자
+func NewExtAuthzServer(authUseCase usecase.AuthUseCase, cfg config.ForwardAuthConfig) *ExtAuthzServer {
+	s := &ExtAuthzServer{
+		authUseCase: authUseCase,
+	}
+	commit, _ := s.Prepare(cfg)
+	commit()
+	return s
+}
+
+// Prepare 새 경로별 aud 규칙으로 교체하는 함수 반환 (hot reload용, 설정은 이미 검증된 상태)
+func (s *ExtAuthzServer) Prepare(cfg config.ForwardAuthConfig) (func(), error) {
+	audiences := proxy.Audiences(cfg.Audiences)
+	return func() { s.audiences.Store(&audiences) }, nil
+}
+
+// Check Envoy 외부 인가 요청 처리
+//
+// 인증 실패도 gRPC 에러가 아닌 Denied 응답으로 돌려줘야 Envoy가 401을 클라이언트에 전달합니다.
+//
+// DPoP 바인딩 토큰은 CheckRequest의 원 요청 메서드와 주소로 DPoP 헤더의 증명을 검증하고,
+// 인증서 바인딩 토큰은 Envoy가 전달한 downstream 클라이언트 인증서와 비교합니다.
+// API 키는 Authorization 또는 X-API-Key 헤더로 받으며 scope를 x-scopes 헤더로 전달합니다.
+// 원 요청 호스트/경로에 aud 규칙(forward_auth.audiences)이 있으면 토큰 aud가 그 규칙과 일치해야 합니다.
+func (s *ExtAuthzServer) Check(ctx context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	httpReq := req.GetAttributes().GetRequest().GetHttp()
+	headers := httpReq.GetHeaders()
+
+	scheme, token := authorizationToken(headers["authorization"])
+	if token == "" {
+		token = strings.TrimSpace(headers[strings.ToLower(apikey.HeaderAPIKey)])
+	}
+	if token == "" {
+		return denied(codes.Unauthenticated, `Bearer realm="qauth"`), nil
+	}
+
+	// 여러 DPoP 헤더는 Envoy가 쉼표로 합쳐 전달하므로 증명 파싱 단계에서 거부됨
+	if strings.EqualFold(scheme, dpop.Scheme) {
+		if headers["dpop"] == "" {
+			return denied(codes.Unauthenticated, `DPoP realm="qauth", error="invalid_dpop_proof"`), nil
+		}
+		ctx = domain.WithProof(ctx, domain.ProofRequest{
+			Proof:     headers["dpop"],
+			Method:    httpReq.GetMethod(),
+			URL:       httpReq.GetScheme() + "://" + httpReq.GetHost() + httpReq.GetPath(),
+			Forwarded: true,
+			Scheme:    true,
+		})
+	}
+
+	// Envoy가 전달한 downstream 클라이언트 인증서 (URL 인코딩 PEM, mTLS가 아니면 빈 값)
+	// ClientCertInterceptor가 넣은 thumbprint는 Envoy 자신의 인증서이므로 downstream 인증서가 없으면 비움
+	var thumbprint string
+	if cert := req.GetAttributes().GetSource().GetCertificate(); cert != "" {
+		var err error
+		thumbprint, err = certbind.ParseForwarded(cert)
+		if err != nil {
+			return denied(codes.Unauthenticated, `Bearer realm="qauth", error="invalid_token"`), nil
+		}
+	}
+	ctx = domain.WithCertificateThumbprint(ctx, thumbprint)
+
+	// API 키 IP 제한은 Envoy가 본 downstream 주소 기준
+	ctx = domain.WithClientIP(ctx, req.GetAttributes().GetSource().GetAddress().GetSocketAddress().GetAddress())
+
+	if audiences, ok := s.audiences.Load().Match(httpReq.GetHost(), httpReq.GetPath()); ok {
+		ctx = domain.WithExpectedAudience(ctx, audiences)
+	}
+
+	resp, err := s.authUseCase.ValidateToken(ctx, token)
+	if errors.Is(err, domain.ErrInvalidDPoPProof) {
+		return denied(codes.Unauthenticated, `DPoP realm="qauth", error="invalid_dpop_proof"`), nil
+	}
+	if err != nil || !resp.Valid {
+		return denied(codes.Unauthenticated, `Bearer realm="qauth", error="invalid_token"`), nil
+	}
+
+	return &authv3.CheckResponse{
+		Status: &status.Status{Code: int32(codes.OK)},
+		HttpResponse: &authv3.CheckResponse_OkResponse{
+			OkResponse: &authv3.OkHttpResponse{
+				Headers: []*corev3.HeaderValueOption{
+					overwrite(headerUserID, resp.UserID),
+					overwrite(headerScopes, strings.Join(resp.Scopes, " ")),
+					overwrite(headerImpersonatedBy, resp.Impersonator()),
+				},
+			},
+		},
+	}, nil
+}
+
+// denied 401 응답을 포함한 거부 결과 생성
+func denied(code codes.Code, challenge string) *authv3.CheckResponse {
+	return &authv3.CheckResponse{
+		Status: &status.Status{Code: int32(code)},
+		HttpResponse: &authv3.CheckResponse_DeniedResponse{
+			DeniedResponse: &authv3.DeniedHttpResponse{
+				Status:  &typev3.HttpStatus{Code: typev3.StatusCode_Unauthorized},
+				Headers: []*corev3.HeaderValueOption{overwrite("www-authenticate", challenge)},
+			},
+		},
+	}
+}
+
+// overwrite 기존 값을 덮어쓰는 헤더 옵션 (클라이언트가 보낸 식별 헤더 위조 방지)
+func overwrite(key, value string) *corev3.HeaderValueOption {
+	return &corev3.HeaderValueOption{
+		Header:       &corev3.HeaderValue{Key: key, Value: value},
+		AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+	}
+}
+
+// authorizationToken Authorization 헤더 값에서 Bearer/DPoP scheme과 토큰 추출
+func authorizationToken(authorization string) (string, string) {
+	scheme, token, ok := strings.Cut(authorization, " ")
+	if !ok || (!strings.EqualFold(scheme, "Bearer") && !strings.EqualFold(scheme, dpop.Scheme)) {
+		return "", ""
+	}
+	return scheme, strings.TrimSpace(token)
+}
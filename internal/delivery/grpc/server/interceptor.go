@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"strings"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/signalable/qauth/internal/audit"
+	"github.com/signalable/qauth/internal/caller"
+	"github.com/signalable/qauth/internal/certbind"
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/requestid"
+	"github.com/signalable/qauth/internal/tlsconfig"
+	qauthv1 "github.com/signalable/qauth/pkg/proto/qauth/v1"
+)
+
+// RequestInfoInterceptor 요청 ID와 감사용 요청 출처 정보를 context에 추가하는 인터셉터
+func RequestInfoInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var info audit.RequestInfo
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		info.IP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(info.IP); err == nil {
+			info.IP = host
+		}
+	}
+
+	id := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		info.UserAgent = first(md.Get("user-agent"))
+		info.Client = first(md.Get("x-client-id"))
+		id = first(md.Get("x-request-id"))
+	}
+	if id == "" {
+		id = requestid.New()
+	}
+
+	ctx = requestid.WithContext(ctx, id)
+	return handler(audit.WithRequestInfo(ctx, info), req)
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// InternalOperations 호출자 인증이 필요한 내부 RPC별 작업
+var InternalOperations = map[string]string{
+	qauthv1.AuthService_CreateToken_FullMethodName:      caller.OpTokenCreate,
+	qauthv1.AuthService_ValidateToken_FullMethodName:    caller.OpTokenValidate,
+	qauthv1.AuthService_GetTokenMetadata_FullMethodName: caller.OpTokenValidate,
+	qauthv1.AuthService_RevokeAllTokens_FullMethodName:  caller.OpAdminSessions,
+
+	// Envoy ext_authz (Envoy는 grpc_service.initial_metadata의 API 키나 클라이언트 인증서로 인증)
+	authv3.Authorization_Check_FullMethodName: caller.OpTokenValidate,
+}
+
+// PublicMethods 호출자 인증 없이 허용하는 AuthService RPC (요청의 토큰 자체가 자격 증명)
+//
+// InternalOperations와 여기 모두 없는 AuthService RPC는 거부하므로 RPC를 추가하면 둘 중 한쪽에 등록해야 합니다.
+var PublicMethods = map[string]bool{
+	qauthv1.AuthService_RevokeToken_FullMethodName:  true,
+	qauthv1.AuthService_RefreshToken_FullMethodName: true,
+}
+
+// authServicePrefix AuthService RPC 전체 메서드 이름 접두사
+var authServicePrefix = "/" + qauthv1.AuthService_ServiceDesc.ServiceName + "/"
+
+// ClientCertInterceptor 검증된 클라이언트 인증서의 호출자 식별자를 감사 정보에 기록하고
+// 인증서 바인딩 토큰 확인용 thumbprint를 context에 추가하는 인터셉터 (RequestInfoInterceptor 뒤에 등록)
+func ClientCertInterceptor(m *tlsconfig.Manager) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if identity := certIdentity(ctx, m); identity != "" {
+			requestInfo := audit.RequestInfoFromContext(ctx)
+			requestInfo.Client = identity
+			ctx = audit.WithRequestInfo(ctx, requestInfo)
+		}
+		if cert := verifiedCert(ctx); cert != nil {
+			ctx = domain.WithCertificateThumbprint(ctx, certbind.Thumbprint(cert))
+		}
+		return handler(ctx, req)
+	}
+}
+
+// CallerAuthInterceptor 내부 RPC의 호출자를 인증하고 작업 허용 여부를 확인하는 인터셉터
+//
+// gRPC에는 본문 서명을 적용할 수 없으므로 auth에는 API 키와 클라이언트 인증서 방식만 등록합니다.
+// 인증된 호출자는 context에 추가하고 호출자 ID는 감사 정보의 client로 기록합니다.
+// operations와 public 어디에도 없는 AuthService RPC는 PermissionDenied로 거부합니다.
+func CallerAuthInterceptor(auth *caller.Auth, m *tlsconfig.Manager, operations map[string]string, public map[string]bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		operation, ok := operations[info.FullMethod]
+		if !ok {
+			if strings.HasPrefix(info.FullMethod, authServicePrefix) && !public[info.FullMethod] {
+				return nil, status.Error(codes.PermissionDenied, "허용되지 않은 RPC입니다")
+			}
+			return handler(ctx, req)
+		}
+
+		header := http.Header{}
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			for k, v := range md {
+				header[http.CanonicalHeaderKey(k)] = v
+			}
+		}
+
+		c, err := auth.Authorize(ctx, &caller.Request{
+			Method:       "POST",
+			URI:          info.FullMethod,
+			Header:       header,
+			CertIdentity: certIdentity(ctx, m),
+			RemoteAddr:   peerAddr(ctx),
+		}, operation)
+		if err != nil {
+			return nil, toStatus(err, codes.Unauthenticated)
+		}
+
+		ctx = caller.WithContext(ctx, c)
+		if c.ID != "" {
+			requestInfo := audit.RequestInfoFromContext(ctx)
+			requestInfo.Client = c.ID
+			ctx = audit.WithRequestInfo(ctx, requestInfo)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// peerAddr 연결 주소 (알 수 없으면 빈 문자열)
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// certIdentity 검증된 클라이언트 인증서의 호출자 식별자 (없으면 빈 문자열)
+func certIdentity(ctx context.Context, m *tlsconfig.Manager) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			return m.VerifiedIdentity(&tlsInfo.State)
+		}
+	}
+	return ""
+}
+
+// verifiedCert 검증된 클라이언트 인증서 (없으면 nil)
+func verifiedCert(ctx context.Context) *x509.Certificate {
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			if chains := tlsInfo.State.VerifiedChains; len(chains) > 0 && len(chains[0]) > 0 {
+				return chains[0][0]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package server
+
+import (
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	qauthv1 "github.com/signalable/qauth/pkg/proto/qauth/v1"
+)
+
+// NewGRPCServer Auth 서비스, Envoy ext_authz, 헬스 체크, 리플렉션이 등록된 gRPC 서버 생성
+func NewGRPCServer(authServer *AuthServer, extAuthzServer *ExtAuthzServer, opts ...grpc.ServerOption) (*grpc.Server, *health.Server) {
+	grpcServer := grpc.NewServer(opts...)
+
+	qauthv1.RegisterAuthServiceServer(grpcServer, authServer)
+	authv3.RegisterAuthorizationServer(grpcServer, extAuthzServer)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(qauthv1.AuthService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(authv3.Authorization_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	reflection.Register(grpcServer)
+
+	return grpcServer, healthServer
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/signalable/qauth/internal/apikey"
+	"github.com/signalable/qauth/internal/delivery/http/response"
+	"github.com/signalable/qauth/internal/domain"
+)
+
+// maxAPIKeyRequestBytes API 키 관리 요청 본문 최대 크기
+const maxAPIKeyRequestBytes = 64 << 10
+
+type APIKeyHandler struct {
+	manager *apikey.Manager
+}
+
+// RotateAPIKeyRequest API 키 교체 요청 (grace_period 동안 이전 키도 사용 가능, 예: "24h")
+type RotateAPIKeyRequest struct {
+	GracePeriod string `json:"grace_period,omitempty"`
+}
+
+// NewAPIKeyHandler API 키 관리 핸들러 생성자
+func NewAPIKeyHandler(manager *apikey.Manager) *APIKeyHandler {
+	return &APIKeyHandler{
+		manager: manager,
+	}
+}
+
+// Issue API 키 발급 핸들러 (키 원문은 이 응답에서만 확인 가능)
+func (h *APIKeyHandler) Issue(w http.ResponseWriter, r *http.Request) {
+	var req domain.APIKeyRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	issued, err := h.manager.Issue(r.Context(), req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, issued)
+}
+
+// List API 키 목록 핸들러 (?user_id=로 사용자별 조회)
+func (h *APIKeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.manager.List(r.Context(), r.URL.Query().Get("user_id"))
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, keys)
+}
+
+// Get API 키 정보 핸들러
+func (h *APIKeyHandler) Get(w http.ResponseWriter, r *http.Request) {
+	key, err := h.manager.Get(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, key)
+}
+
+// Rotate API 키 교체 핸들러
+func (h *APIKeyHandler) Rotate(w http.ResponseWriter, r *http.Request) {
+	var req RotateAPIKeyRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	var grace time.Duration
+	if req.GracePeriod != "" {
+		d, err := time.ParseDuration(req.GracePeriod)
+		if err != nil {
+			response.Error(w, r, domain.ErrInvalidRequest)
+			return
+		}
+		grace = d
+	}
+
+	issued, err := h.manager.Rotate(r.Context(), mux.Vars(r)["id"], grace)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, issued)
+}
+
+// Revoke API 키 폐기 핸들러
+func (h *APIKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	if err := h.manager.Revoke(r.Context(), mux.Vars(r)["id"]); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{
+		"message": "API 키가 폐기되었습니다",
+	})
+}
+
+// decodeJSON 요청 본문 JSON 디코딩 (빈 본문은 기본값 유지)
+func decodeJSON(r *http.Request, v interface{}) error {
+	err := json.NewDecoder(io.LimitReader(r.Body, maxAPIKeyRequestBytes)).Decode(v)
+	if err != nil && err != io.EOF {
+		return domain.ErrInvalidRequest
+	}
+	return nil
+}
@@ -1,80 +1,102 @@
 package handler
 
 import (
-	"encoding/json"
-	"log"
+	"log/slog"
 	"net/http"
 	"strings"
 
+	"github.com/signalable/qauth/internal/apikey"
+	"github.com/signalable/qauth/internal/certbind"
+	"github.com/signalable/qauth/internal/delivery/http/response"
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/dpop"
+	"github.com/signalable/qauth/internal/logger"
 	"github.com/signalable/qauth/internal/usecase"
 )
 
 type AuthHandler struct {
 	authUseCase usecase.AuthUseCase
+	certs       *certbind.Resolver
 }
 
-// NewAuthHandler Auth 핸들러 생성자
-func NewAuthHandler(authUseCase usecase.AuthUseCase) *AuthHandler {
+// NewAuthHandler Auth 핸들러 생성자 (certs는 내부 호출자가 전달한 클라이언트 인증서 확인용)
+func NewAuthHandler(authUseCase usecase.AuthUseCase, certs *certbind.Resolver) *AuthHandler {
 	return &AuthHandler{
 		authUseCase: authUseCase,
+		certs:       certs,
 	}
 }
 
 // CreateToken 토큰 생성 핸들러
+//
+// User Service가 클라이언트의 DPoP 헤더와 원 요청(X-Forwarded-*)을 함께 전달하면
+// 증명 키에 바인딩된 토큰을 발급합니다.
 func (h *AuthHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
 	userID := r.Header.Get("X-User-ID") // User Service에서 전달받은 사용자 ID
 	if userID == "" {
-		http.Error(w, "사용자 ID가 필요합니다", http.StatusBadRequest)
+		response.Error(w, r, domain.ErrInvalidRequest)
+		return
+	}
+
+	r, err := h.attachProofs(r)
+	if err != nil {
+		response.Error(w, r, err)
 		return
 	}
 
 	resp, err := h.authUseCase.CreateToken(r.Context(), userID)
 	if err != nil {
-		http.Error(w, "토큰 생성 실패", http.StatusInternalServerError)
+		response.Error(w, r, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	response.JSON(w, http.StatusOK, resp)
 }
 
 // ValidateToken 토큰 검증 핸들러
+//
+// DPoP 바인딩 토큰은 리소스 서버가 받은 DPoP 헤더와 원 요청(X-Forwarded-*)을, 인증서 바인딩 토큰은
+// 리소스 서버가 받은 클라이언트 인증서를 인증서 전달 헤더로 함께 보내야 합니다.
 func (h *AuthHandler) ValidateToken(w http.ResponseWriter, r *http.Request) {
 	token := extractToken(r)
 	if token == "" {
-		http.Error(w, "토큰이 필요합니다", http.StatusBadRequest)
+		response.Error(w, r, domain.ErrMissingToken)
+		return
+	}
+
+	r, err := h.attachProofs(r)
+	if err != nil {
+		response.Error(w, r, err)
 		return
 	}
 
-	// 디버깅을 위한 로그 추가
-	log.Printf("Received token for validation: %s", token)
+	// 디버깅을 위한 로그 추가 (토큰 원문 대신 지문만 기록)
+	slog.DebugContext(r.Context(), "토큰 검증 요청 수신", logger.Token(token))
 
 	resp, err := h.authUseCase.ValidateToken(r.Context(), token)
 	if err != nil {
-		log.Printf("Token validation error: %v", err) // 에러 로깅 추가
-		http.Error(w, err.Error(), http.StatusUnauthorized)
+		slog.InfoContext(r.Context(), "토큰 검증 실패", logger.Token(token), slog.Any("error", err))
+		response.Error(w, r, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	response.JSON(w, http.StatusOK, resp)
 }
 
 // RevokeToken 토큰 폐기 핸들러
 func (h *AuthHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
 	token := extractToken(r)
 	if token == "" {
-		http.Error(w, "토큰이 필요합니다", http.StatusBadRequest)
+		response.Error(w, r, domain.ErrMissingToken)
 		return
 	}
 
 	if err := h.authUseCase.RevokeToken(r.Context(), token); err != nil {
-		http.Error(w, "토큰 폐기 실패", http.StatusInternalServerError)
+		response.Error(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{
+	response.JSON(w, http.StatusOK, map[string]string{
 		"message": "토큰이 폐기되었습니다",
 	})
 }
@@ -83,25 +105,67 @@ func (h *AuthHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	token := extractToken(r)
 	if token == "" {
-		http.Error(w, "토큰이 필요합니다", http.StatusBadRequest)
+		response.Error(w, r, domain.ErrMissingToken)
+		return
+	}
+
+	r, err := dpop.Attach(r, false)
+	if err != nil {
+		response.Error(w, r, err)
 		return
 	}
 
 	resp, err := h.authUseCase.RefreshToken(r.Context(), token)
 	if err != nil {
-		http.Error(w, "토큰 새로고침 실패", http.StatusUnauthorized)
+		response.Error(w, r, err)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	response.JSON(w, http.StatusOK, resp)
+}
+
+// attachProofs 내부 호출자가 전달한 DPoP 증명, 클라이언트 인증서, 클라이언트 IP를 context에 추가
+func (h *AuthHandler) attachProofs(r *http.Request) (*http.Request, error) {
+	r, err := dpop.Attach(r, true)
+	if err != nil {
+		return r, err
+	}
+	r = apikey.AttachClientIP(r, true)
+	if h.certs == nil {
+		return r, nil
+	}
+	return h.certs.Attach(r, true)
+}
+
+// TokenStatus 토큰 상태 조회 핸들러
+//
+// 서명, 만료, 폐기 여부만 확인하고 키 바인딩의 소유 증명은 확인하지 않으므로
+// 바인딩을 직접 확인하는 리소스 서버(pkg/verifier 등)의 폐기 확인용입니다.
+func (h *AuthHandler) TokenStatus(w http.ResponseWriter, r *http.Request) {
+	token := extractToken(r)
+	if token == "" {
+		response.Error(w, r, domain.ErrMissingToken)
+		return
+	}
+
+	metadata, err := h.authUseCase.GetTokenMetadata(r.Context(), token)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, domain.TokenStatusResponse{
+		Active:    true,
+		UserID:    metadata.UserID,
+		ExpiresAt: metadata.ExpiresAt,
+	})
 }
 
-// extractToken 요청에서 토큰 추출
+// extractToken 요청에서 토큰 추출 (Authorization이 없으면 X-API-Key의 API 키)
 func extractToken(r *http.Request) string {
 	bearerToken := r.Header.Get("Authorization")
 	if len(strings.Split(bearerToken, " ")) == 2 {
 		return strings.Split(bearerToken, " ")[1]
 	}
-	return ""
+	return r.Header.Get(apikey.HeaderAPIKey)
 }
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/signalable/qauth/internal/apikey"
+	"github.com/signalable/qauth/internal/config"
+	"github.com/signalable/qauth/internal/delivery/http/response"
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/dpop"
+	"github.com/signalable/qauth/internal/proxy"
+	"github.com/signalable/qauth/internal/usecase"
+)
+
+// 업스트림으로 전달되는 식별 헤더
+const (
+	HeaderUserID = "X-User-ID"
+
+	// HeaderScopes 자격 증명에 허용된 scope (공백 구분, 없으면 빈 값)
+	HeaderScopes = "X-Scopes"
+
+	// HeaderImpersonatedBy 관리자 대리 로그인 토큰이면 대리 로그인한 관리자 ID (아니면 빈 값)
+	HeaderImpersonatedBy = "X-Impersonated-By"
+)
+
+// extAuthzPrefix Envoy ext_authz HTTP 모드 경로 prefix (뒤에 원 요청 경로가 붙음)
+const extAuthzPrefix = "/api/auth/ext_authz"
+
+type ForwardAuthHandler struct {
+	authUseCase usecase.AuthUseCase
+	state       atomic.Pointer[forwardAuthState]
+}
+
+// forwardAuthState 한 시점의 프록시 위임 인증 설정
+type forwardAuthState struct {
+	proxies   proxy.Trusted
+	audiences proxy.Audiences
+}
+
+// NewForwardAuthHandler 프록시 위임 인증 핸들러 생성자
+func NewForwardAuthHandler(authUseCase usecase.AuthUseCase, cfg config.ForwardAuthConfig) (*ForwardAuthHandler, error) {
+	h := &ForwardAuthHandler{
+		authUseCase: authUseCase,
+	}
+	commit, err := h.Prepare(cfg)
+	if err != nil {
+		return nil, err
+	}
+	commit()
+	return h, nil
+}
+
+// Prepare 새 신뢰 프록시 목록과 경로별 aud 규칙 검증 후 적용 함수 반환 (hot reload용)
+func (h *ForwardAuthHandler) Prepare(cfg config.ForwardAuthConfig) (func(), error) {
+	proxies, err := proxy.ParseTrusted(cfg.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("신뢰 프록시 주소 파싱 실패: %w", err)
+	}
+	st := &forwardAuthState{proxies: proxies, audiences: cfg.Audiences}
+	return func() { h.state.Store(st) }, nil
+}
+
+// ForwardAuth 프록시 위임 인증 핸들러
+//
+// nginx auth_request, Traefik ForwardAuth, Envoy ext_authz(HTTP 모드)가 원 요청의
+// 헤더를 그대로 전달하면 Bearer 토큰을 검증하고, 성공 시 200과 함께 식별 헤더를
+// 응답합니다. 프록시는 이 헤더를 업스트림 요청에 덮어써야 합니다
+// (클라이언트가 보낸 X-User-ID를 그대로 신뢰하지 않도록).
+//
+// 신뢰하는 프록시에서 온 요청이면 DPoP 바인딩 토큰은 X-Forwarded-Method/Proto/Host/Uri의 원 요청 기준으로
+// 증명을 검증하고, API 키의 IP 제한은 X-Forwarded-For의 클라이언트 주소 기준으로 확인합니다.
+// 그 외의 요청은 클라이언트가 꾸밀 수 있으므로 X-Forwarded-*를 무시하고 연결 주소 기준으로 확인합니다.
+// 신뢰하는 프록시가 전달한 원 요청 호스트/경로에 aud 규칙이 있으면 토큰 aud가 그 규칙과 일치해야 하고,
+// 없으면 검증을 요청한 호출자의 audiences 기준으로 확인합니다.
+func (h *ForwardAuthHandler) ForwardAuth(w http.ResponseWriter, r *http.Request) {
+	// CORS preflight는 인증 없이 통과
+	if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	token := extractToken(r)
+	if token == "" {
+		response.Error(w, r, domain.ErrMissingToken)
+		return
+	}
+
+	st := h.state.Load()
+	forwarded := st.proxies.Contains(r.RemoteAddr)
+
+	// Envoy는 원 요청 경로를 prefix 뒤에 붙여 보내므로 X-Forwarded-Uri가 없으면 그 경로 사용
+	if forwarded && r.Header.Get(dpop.HeaderForwardedURI) == "" {
+		if path, ok := strings.CutPrefix(r.URL.RequestURI(), extAuthzPrefix); ok && path != "" {
+			r.Header.Set(dpop.HeaderForwardedURI, path)
+		}
+	}
+	r, err := dpop.Attach(r, forwarded)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	r = apikey.AttachClientIP(r, forwarded)
+
+	// 경로별 aud 규칙은 신뢰하는 프록시가 전달한 원 요청에만 적용 (Host는 원 요청 그대로 전달되기도 함)
+	if forwarded {
+		host := r.Header.Get(dpop.HeaderForwardedHost)
+		if host == "" {
+			host = r.Host
+		}
+		if audiences, ok := st.audiences.Match(host, r.Header.Get(dpop.HeaderForwardedURI)); ok {
+			r = r.WithContext(domain.WithExpectedAudience(r.Context(), audiences))
+		}
+	}
+
+	resp, err := h.authUseCase.ValidateToken(r.Context(), token)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	if !resp.Valid {
+		response.Error(w, r, domain.ErrInvalidToken)
+		return
+	}
+
+	w.Header().Set(HeaderUserID, resp.UserID)
+	w.Header().Set(HeaderScopes, strings.Join(resp.Scopes, " "))
+	w.Header().Set(HeaderImpersonatedBy, resp.Impersonator())
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/signalable/qauth/internal/delivery/http/response"
+	"github.com/signalable/qauth/pkg/jwt"
+)
+
+// readinessTimeout 준비 상태 점검 하나에 허용하는 시간
+const readinessTimeout = 2 * time.Second
+
+type HealthHandler struct {
+	redisClient *redis.Client
+	jwtService  *jwt.Service
+	draining    atomic.Bool
+}
+
+// HealthResponse 헬스 체크 응답
+type HealthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// NewHealthHandler 헬스 체크 핸들러 생성자
+func NewHealthHandler(redisClient *redis.Client, jwtService *jwt.Service) *HealthHandler {
+	return &HealthHandler{
+		redisClient: redisClient,
+		jwtService:  jwtService,
+	}
+}
+
+// Drain 종료 준비 시작 (이후 readiness는 항상 실패해 로드밸런서에서 제외됨)
+func (h *HealthHandler) Drain() {
+	h.draining.Store(true)
+}
+
+// Liveness 프로세스 생존 여부 핸들러 (외부 의존성은 확인하지 않음)
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	response.JSON(w, http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+// Readiness 요청 처리 가능 여부 핸들러 (Redis 연결과 서명 키 확인)
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	if h.draining.Load() {
+		response.JSON(w, http.StatusServiceUnavailable, HealthResponse{Status: "draining"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	checks := map[string]string{}
+	ready := true
+
+	if err := h.redisClient.Ping(ctx).Err(); err != nil {
+		slog.WarnContext(ctx, "readiness: Redis 연결 실패", slog.Any("error", err))
+		checks["redis"] = "unavailable"
+		ready = false
+	} else {
+		checks["redis"] = "ok"
+	}
+
+	if err := h.jwtService.CheckKeys(); err != nil {
+		slog.WarnContext(ctx, "readiness: 서명 키 사용 불가", slog.Any("error", err))
+		checks["keys"] = "unavailable"
+		ready = false
+	} else {
+		checks["keys"] = "ok"
+	}
+
+	if !ready {
+		response.JSON(w, http.StatusServiceUnavailable, HealthResponse{Status: "unavailable", Checks: checks})
+		return
+	}
+	response.JSON(w, http.StatusOK, HealthResponse{Status: "ready", Checks: checks})
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/signalable/qauth/internal/delivery/http/response"
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/impersonation"
+)
+
+type ImpersonationHandler struct {
+	manager *impersonation.Manager
+}
+
+// NewImpersonationHandler 관리자 대리 로그인 핸들러 생성자
+func NewImpersonationHandler(manager *impersonation.Manager) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		manager: manager,
+	}
+}
+
+// Start 대리 로그인 시작 핸들러 (토큰 원문은 이 응답에서만 확인 가능)
+func (h *ImpersonationHandler) Start(w http.ResponseWriter, r *http.Request) {
+	var req domain.ImpersonationRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+	req.AdminToken = r.Header.Get(impersonation.HeaderAdminToken)
+
+	issued, err := h.manager.Start(r.Context(), req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	response.JSON(w, http.StatusCreated, issued)
+}
+
+// List 대리 로그인 세션 목록 핸들러 (?user_id=로 고객별 조회)
+func (h *ImpersonationHandler) List(w http.ResponseWriter, r *http.Request) {
+	sessions, err := h.manager.List(r.Context(), r.URL.Query().Get("user_id"))
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, sessions)
+}
+
+// Get 대리 로그인 세션 조회 핸들러
+func (h *ImpersonationHandler) Get(w http.ResponseWriter, r *http.Request) {
+	session, err := h.manager.Get(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, session)
+}
+
+// Revoke 대리 로그인 토큰 폐기 핸들러 (폐기한 관리자는 관리자 토큰의 사용자로 기록)
+func (h *ImpersonationHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	session, err := h.manager.Revoke(r.Context(), mux.Vars(r)["id"], r.Header.Get(impersonation.HeaderAdminToken))
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, session)
+}
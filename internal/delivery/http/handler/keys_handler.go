@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/signalable/qauth/pkg/jwt"
+)
+
+type KeysHandler struct {
+	jwtService *jwt.Service
+}
+
+// NewKeysHandler 서명 키 핸들러 생성자
+func NewKeysHandler(jwtService *jwt.Service) *KeysHandler {
+	return &KeysHandler{
+		jwtService: jwtService,
+	}
+}
+
+// JWKS 토큰 서명 공개키 목록 핸들러
+func (h *KeysHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	json.NewEncoder(w).Encode(h.jwtService.JWKS())
+}
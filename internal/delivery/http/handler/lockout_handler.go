@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/signalable/qauth/internal/delivery/http/response"
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/lockout"
+)
+
+type LockoutHandler struct {
+	guard *lockout.Guard
+}
+
+// NewLockoutHandler 로그인 잠금 관리 핸들러 생성자
+func NewLockoutHandler(guard *lockout.Guard) *LockoutHandler {
+	return &LockoutHandler{
+		guard: guard,
+	}
+}
+
+// CheckLogin 로그인 시도 허용 여부 핸들러 (User Service가 자격 증명을 확인하기 전에 호출)
+func (h *LockoutHandler) CheckLogin(w http.ResponseWriter, r *http.Request) {
+	attempt, err := decodeLoginAttempt(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	err = h.guard.Check(r.Context(), attempt.Account, attempt.IP)
+	if err != nil && !errors.Is(err, domain.ErrAuthenticationFailed) {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, domain.LoginCheckResponse{Allowed: err == nil})
+}
+
+// LoginFailed 로그인 실패 보고 핸들러
+func (h *LockoutHandler) LoginFailed(w http.ResponseWriter, r *http.Request) {
+	attempt, err := decodeLoginAttempt(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	if err := h.guard.Fail(r.Context(), attempt.Account, attempt.IP); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// LoginSucceeded 로그인 성공 보고 핸들러
+func (h *LockoutHandler) LoginSucceeded(w http.ResponseWriter, r *http.Request) {
+	attempt, err := decodeLoginAttempt(r)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	if err := h.guard.Succeed(r.Context(), attempt.Account, attempt.IP); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListLocked 현재 잠긴 계정/IP 목록 핸들러
+func (h *LockoutHandler) ListLocked(w http.ResponseWriter, r *http.Request) {
+	statuses, err := h.guard.Locked(r.Context())
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, statuses)
+}
+
+// Status 계정/IP별 실패 및 잠금 상태 핸들러
+func (h *LockoutHandler) Status(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	status, err := h.guard.Status(r.Context(), vars["kind"], vars["id"])
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, status)
+}
+
+// Clear 계정/IP 잠금 해제 핸들러
+func (h *LockoutHandler) Clear(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	if err := h.guard.Clear(r.Context(), vars["kind"], vars["id"]); err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, map[string]string{
+		"message": "잠금이 해제되었습니다",
+	})
+}
+
+// decodeLoginAttempt 로그인 시도 요청 디코딩 (계정 필수, IP는 있으면 IP 주소여야 함)
+func decodeLoginAttempt(r *http.Request) (*domain.LoginAttempt, error) {
+	var attempt domain.LoginAttempt
+	if err := decodeJSON(r, &attempt); err != nil {
+		return nil, err
+	}
+	if attempt.Account == "" {
+		return nil, fmt.Errorf("%w: account가 필요합니다", domain.ErrInvalidRequest)
+	}
+	if attempt.IP != "" && net.ParseIP(attempt.IP) == nil {
+		return nil, fmt.Errorf("%w: 잘못된 IP 주소입니다", domain.ErrInvalidRequest)
+	}
+	return &attempt, nil
+}
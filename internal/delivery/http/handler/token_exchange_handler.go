@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/signalable/qauth/internal/delivery/http/response"
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/usecase"
+)
+
+// maxTokenExchangeRequestBytes 토큰 교환 요청 본문 최대 크기
+const maxTokenExchangeRequestBytes = 64 << 10
+
+type TokenExchangeHandler struct {
+	exchangeUseCase usecase.TokenExchangeUseCase
+}
+
+// NewTokenExchangeHandler 토큰 교환 핸들러 생성자
+func NewTokenExchangeHandler(exchangeUseCase usecase.TokenExchangeUseCase) *TokenExchangeHandler {
+	return &TokenExchangeHandler{
+		exchangeUseCase: exchangeUseCase,
+	}
+}
+
+// ExchangeToken 토큰 교환 핸들러 (RFC 8693 2.1, application/x-www-form-urlencoded 본문)
+func (h *TokenExchangeHandler) ExchangeToken(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxTokenExchangeRequestBytes)
+	if err := r.ParseForm(); err != nil {
+		response.Error(w, r, domain.ErrInvalidRequest)
+		return
+	}
+	if r.PostForm.Get("grant_type") != domain.GrantTypeTokenExchange {
+		response.Error(w, r, domain.ErrInvalidRequest)
+		return
+	}
+
+	req := &domain.TokenExchangeRequest{
+		SubjectToken:       r.PostForm.Get("subject_token"),
+		SubjectTokenType:   r.PostForm.Get("subject_token_type"),
+		ActorToken:         r.PostForm.Get("actor_token"),
+		ActorTokenType:     r.PostForm.Get("actor_token_type"),
+		Audience:           r.PostForm.Get("audience"),
+		Scopes:             strings.Fields(r.PostForm.Get("scope")),
+		RequestedTokenType: r.PostForm.Get("requested_token_type"),
+	}
+
+	resp, err := h.exchangeUseCase.ExchangeToken(r.Context(), req)
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	// 발급한 토큰이 캐시되지 않도록 (RFC 6749 5.1)
+	w.Header().Set("Cache-Control", "no-store")
+	response.JSON(w, http.StatusOK, resp)
+}
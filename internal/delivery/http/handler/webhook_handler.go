@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/signalable/qauth/internal/delivery/http/response"
+	"github.com/signalable/qauth/internal/webhook"
+)
+
+type WebhookHandler struct {
+	dispatcher *webhook.Dispatcher
+}
+
+// ReplayResponse 실패 전송 재전송 결과
+type ReplayResponse struct {
+	Delivered bool              `json:"delivered"`
+	Delivery  *webhook.Delivery `json:"delivery"`
+}
+
+// NewWebhookHandler 웹훅 관리 핸들러 생성자
+func NewWebhookHandler(dispatcher *webhook.Dispatcher) *WebhookHandler {
+	return &WebhookHandler{
+		dispatcher: dispatcher,
+	}
+}
+
+// ListDeadLetters 실패 전송 목록 핸들러
+func (h *WebhookHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	deliveries, err := h.dispatcher.DeadLetters(r.Context())
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, deliveries)
+}
+
+// Replay 실패 전송 재전송 핸들러
+func (h *WebhookHandler) Replay(w http.ResponseWriter, r *http.Request) {
+	delivery, err := h.dispatcher.Replay(r.Context(), mux.Vars(r)["id"])
+	if errors.Is(err, webhook.ErrDeliveryFailed) {
+		slog.WarnContext(r.Context(), "웹훅 재전송 실패", slog.String("delivery_id", delivery.ID), slog.Any("error", err))
+		response.JSON(w, http.StatusBadGateway, ReplayResponse{Delivered: false, Delivery: delivery})
+		return
+	}
+	if err != nil {
+		response.Error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, ReplayResponse{Delivered: true, Delivery: delivery})
+}
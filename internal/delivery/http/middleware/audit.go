@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/signalable/qauth/internal/audit"
+)
+
+// HeaderClientID 호출 클라이언트 식별 헤더
+const HeaderClientID = "X-Client-ID"
+
+// AuditContext 감사 이벤트에 기록할 요청 출처 정보를 context에 추가하는 미들웨어
+func AuditContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+
+		ctx := audit.WithRequestInfo(r.Context(), audit.RequestInfo{
+			IP:        ip,
+			UserAgent: r.UserAgent(),
+			Client:    r.Header.Get(HeaderClientID),
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
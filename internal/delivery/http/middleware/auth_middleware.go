@@ -5,11 +5,17 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/signalable/qauth/internal/apikey"
+	"github.com/signalable/qauth/internal/audit"
+	"github.com/signalable/qauth/internal/delivery/http/response"
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/dpop"
 	"github.com/signalable/qauth/internal/usecase"
 )
 
 type AuthMiddleware struct {
 	authUseCase usecase.AuthUseCase
+	after       []func(http.HandlerFunc) http.HandlerFunc
 }
 
 // NewAuthMiddleware Auth 미들웨어 생성자
@@ -19,30 +25,61 @@ func NewAuthMiddleware(authUseCase usecase.AuthUseCase) *AuthMiddleware {
 	}
 }
 
+// Use 인증을 통과한 요청에 적용할 미들웨어 등록 (사용자 기준 요청 제한 등, 라우터 설정 전에 호출)
+func (m *AuthMiddleware) Use(mw func(http.HandlerFunc) http.HandlerFunc) {
+	m.after = append(m.after, mw)
+}
+
 // Authenticate 인증 미들웨어
+//
+// Bearer 토큰과 DPoP 바인딩 토큰(Authorization: DPoP + DPoP 증명 헤더)을 모두 받으며,
+// API 키는 Authorization: Bearer 또는 X-API-Key 헤더로 받습니다.
 func (m *AuthMiddleware) Authenticate(next http.HandlerFunc) http.HandlerFunc {
+	for i := len(m.after) - 1; i >= 0; i-- {
+		next = m.after[i](next)
+	}
 	return func(w http.ResponseWriter, r *http.Request) {
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			http.Error(w, "인증이 필요합니다", http.StatusUnauthorized)
+		credential, err := credential(r)
+		if err != nil {
+			response.Error(w, r, err)
 			return
 		}
 
-		// Bearer 토큰 검증
-		tokenParts := strings.Split(authHeader, " ")
-		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			http.Error(w, "잘못된 인증 형식입니다", http.StatusUnauthorized)
+		r, err = dpop.Attach(r, false)
+		if err != nil {
+			response.Error(w, r, err)
 			return
 		}
+		r = apikey.AttachClientIP(r, false)
 
-		token, err := m.authUseCase.ValidateToken(r.Context(), tokenParts[1])
+		token, err := m.authUseCase.ValidateToken(r.Context(), credential)
 		if err != nil {
-			http.Error(w, "유효하지 않은 토큰입니다", http.StatusUnauthorized)
+			response.Error(w, r, err)
 			return
 		}
 
-		// Context에 사용자 ID 추가
+		// Context에 사용자 ID 추가 (감사 정보에도 인증된 사용자로 기록)
 		ctx := context.WithValue(r.Context(), "user_id", token.UserID)
+		info := audit.RequestInfoFromContext(ctx)
+		info.User = token.UserID
+		ctx = audit.WithRequestInfo(ctx, info)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	}
 }
+
+// credential Authorization 헤더의 Bearer/DPoP 토큰 또는 X-API-Key 헤더의 API 키
+func credential(r *http.Request) (string, error) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		if key := r.Header.Get(apikey.HeaderAPIKey); key != "" {
+			return key, nil
+		}
+		return "", domain.ErrMissingToken
+	}
+
+	tokenParts := strings.Split(authHeader, " ")
+	if len(tokenParts) != 2 || (tokenParts[0] != "Bearer" && tokenParts[0] != dpop.Scheme) {
+		return "", domain.ErrInvalidRequest
+	}
+	return tokenParts[1], nil
+}
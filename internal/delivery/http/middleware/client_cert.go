@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/signalable/qauth/internal/audit"
+	"github.com/signalable/qauth/internal/tlsconfig"
+)
+
+type ClientCertMiddleware struct {
+	tls *tlsconfig.Manager
+}
+
+// NewClientCertMiddleware 클라이언트 인증서 미들웨어 생성자 (tls가 nil이면 TLS 미사용으로 간주)
+func NewClientCertMiddleware(tls *tlsconfig.Manager) *ClientCertMiddleware {
+	return &ClientCertMiddleware{
+		tls: tls,
+	}
+}
+
+// Identify 검증된 클라이언트 인증서가 있으면 호출자 식별자를 감사 정보의 client로 기록
+//
+// AuditContext 뒤에 등록해야 하며, 인증서 식별자가 X-Client-ID 헤더보다 우선합니다.
+func (m *ClientCertMiddleware) Identify(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if identity := m.tls.VerifiedIdentity(r.TLS); identity != "" {
+			info := audit.RequestInfoFromContext(r.Context())
+			info.Client = identity
+			r = r.WithContext(audit.WithRequestInfo(r.Context(), info))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
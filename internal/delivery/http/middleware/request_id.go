@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/signalable/qauth/internal/requestid"
+)
+
+// maxRequestIDLength 외부에서 전달된 요청 ID 최대 길이
+const maxRequestIDLength = 128
+
+// RequestID 요청 ID 미들웨어 (X-Request-ID가 없거나 비정상이면 새로 생성)
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestid.Header)
+		if !validRequestID(id) {
+			id = requestid.New()
+		}
+
+		w.Header().Set(requestid.Header, id)
+		next.ServeHTTP(w, r.WithContext(requestid.WithContext(r.Context(), id)))
+	})
+}
+
+// validRequestID 로그/헤더에 안전하게 쓸 수 있는 요청 ID인지 검사
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		if c < 0x21 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+package response
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/requestid"
+)
+
+// ErrorResponse JSON 에러 응답
+type ErrorResponse struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// 인증 실패 시 WWW-Authenticate로 보내는 challenge scheme
+const (
+	// schemeBearer 사용자 토큰/API 키 (RFC 6750)
+	schemeBearer = "Bearer"
+
+	// schemeDPoP DPoP 증명 (RFC 9449)
+	schemeDPoP = "DPoP"
+
+	// schemeCaller 내부 API 호출자 인증 (HTTP 메시지 서명, HMAC 서명, 호출자 API 키, mTLS 인증서)
+	schemeCaller = "QAuth-Caller"
+)
+
+// errorSpec 에러 코드별 HTTP 상태와 WWW-Authenticate challenge
+type errorSpec struct {
+	status int
+
+	// scheme 실패한 인증의 challenge scheme (빈 값이면 WWW-Authenticate 미전송)
+	scheme string
+
+	// bearerError WWW-Authenticate의 error 속성 (빈 값이면 속성 없이 challenge만 전송)
+	bearerError string
+}
+
+// errorSpecs domain 에러 코드별 응답 정의
+//
+// forbidden은 인증된 호출자에게 작업이 허용되지 않은 경우이므로 challenge를 보내지 않습니다.
+var errorSpecs = map[string]errorSpec{
+	domain.CodeMissingToken:         {http.StatusUnauthorized, schemeBearer, ""},
+	domain.CodeInvalidRequest:       {http.StatusBadRequest, "", ""},
+	domain.CodeExpiredToken:         {http.StatusUnauthorized, schemeBearer, "invalid_token"},
+	domain.CodeRevokedToken:         {http.StatusUnauthorized, schemeBearer, "invalid_token"},
+	domain.CodeInvalidToken:         {http.StatusUnauthorized, schemeBearer, "invalid_token"},
+	domain.CodeInvalidDPoPProof:     {http.StatusUnauthorized, schemeDPoP, "invalid_dpop_proof"},
+	domain.CodeAuthenticationFailed: {http.StatusUnauthorized, schemeCaller, ""},
+	domain.CodeInvalidCredentials:   {http.StatusUnauthorized, "", ""},
+	domain.CodeForbidden:            {http.StatusForbidden, "", ""},
+	domain.CodeOriginNotAllowed:     {http.StatusForbidden, "", ""},
+	domain.CodeNotFound:             {http.StatusNotFound, "", ""},
+	domain.CodeRateLimited:          {http.StatusTooManyRequests, "", ""},
+	domain.CodeBodyTooLarge:         {http.StatusRequestEntityTooLarge, "", ""},
+	domain.CodeInvalidScope:         {http.StatusBadRequest, "", ""},
+	domain.CodeInvalidTarget:        {http.StatusBadRequest, "", ""},
+	domain.CodeInternalError:        {http.StatusInternalServerError, "", ""},
+}
+
+// Error 에러를 JSON 에러 응답으로 작성
+//
+// domain 에러가 아닌 에러는 내부 정보 노출을 막기 위해 internal_error로 응답합니다.
+func Error(w http.ResponseWriter, r *http.Request, err error) {
+	code := domain.ErrorCode(err)
+	spec := errorSpecs[code]
+	message := Message(code, r.Header.Get("Accept-Language"))
+
+	if spec.scheme != "" {
+		w.Header().Set("WWW-Authenticate", challenge(spec.scheme, spec.bearerError))
+	}
+
+	JSON(w, spec.status, ErrorResponse{
+		Code:      code,
+		Message:   message,
+		RequestID: requestid.FromContext(r.Context()),
+	})
+}
+
+// Status 에러에 대응하는 HTTP 상태 코드
+func Status(err error) int {
+	return errorSpecs[domain.ErrorCode(err)].status
+}
+
+// challenge WWW-Authenticate 헤더 값 생성 (RFC 6750 형식, DPoP 증명 에러는 RFC 9449 DPoP scheme)
+func challenge(scheme, bearerError string) string {
+	if bearerError == "" {
+		return fmt.Sprintf(`%s realm="qauth"`, scheme)
+	}
+	// error_description은 %x20-21 / %x23-5B / %x5D-7E 범위만 허용되므로 영문 메시지 사용
+	return fmt.Sprintf(`%s realm="qauth", error="%s", error_description="%s"`, scheme, bearerError, Message(bearerDescriptionCode(bearerError), "en"))
+}
+
+// bearerDescriptionCode Bearer 에러 설명에 사용할 메시지 코드
+func bearerDescriptionCode(bearerError string) string {
+	if bearerError == "invalid_dpop_proof" {
+		return domain.CodeInvalidDPoPProof
+	}
+	return domain.CodeInvalidToken
+}
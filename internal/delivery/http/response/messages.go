@@ -0,0 +1,66 @@
+package response
+
+import (
+	"golang.org/x/text/language"
+
+	"github.com/signalable/qauth/internal/domain"
+)
+
+// supportedLanguages 지원 언어 (첫 번째가 기본값)
+var supportedLanguages = []language.Tag{
+	language.Korean,
+	language.English,
+}
+
+var matcher = language.NewMatcher(supportedLanguages)
+
+// messages 언어별 에러 메시지
+var messages = map[language.Tag]map[string]string{
+	language.Korean: {
+		domain.CodeInvalidRequest:       "잘못된 요청입니다",
+		domain.CodeMissingToken:         "토큰이 필요합니다",
+		domain.CodeInvalidToken:         "유효하지 않은 토큰입니다",
+		domain.CodeExpiredToken:         "만료된 토큰입니다",
+		domain.CodeRevokedToken:         "폐기된 토큰입니다",
+		domain.CodeInvalidDPoPProof:     "유효하지 않은 DPoP 증명입니다",
+		domain.CodeAuthenticationFailed: "인증에 실패했습니다",
+		domain.CodeInvalidCredentials:   "잘못된 인증 정보입니다",
+		domain.CodeForbidden:            "권한이 없습니다",
+		domain.CodeOriginNotAllowed:     "허용되지 않은 출처입니다",
+		domain.CodeNotFound:             "리소스를 찾을 수 없습니다",
+		domain.CodeRateLimited:          "요청이 너무 많습니다. 잠시 후 다시 시도해 주세요",
+		domain.CodeBodyTooLarge:         "요청 본문이 너무 큽니다",
+		domain.CodeInvalidScope:         "허용되지 않은 scope입니다",
+		domain.CodeInvalidTarget:        "허용되지 않은 대상입니다",
+		domain.CodeInternalError:        "요청 처리 중 오류가 발생했습니다",
+	},
+	language.English: {
+		domain.CodeInvalidRequest:       "The request is invalid",
+		domain.CodeMissingToken:         "A bearer token is required",
+		domain.CodeInvalidToken:         "The access token is invalid",
+		domain.CodeExpiredToken:         "The access token has expired",
+		domain.CodeRevokedToken:         "The access token has been revoked",
+		domain.CodeInvalidDPoPProof:     "The DPoP proof is invalid",
+		domain.CodeAuthenticationFailed: "Authentication failed",
+		domain.CodeInvalidCredentials:   "The credentials are invalid",
+		domain.CodeForbidden:            "Insufficient permissions",
+		domain.CodeOriginNotAllowed:     "The request origin is not allowed",
+		domain.CodeNotFound:             "The resource was not found",
+		domain.CodeRateLimited:          "Too many requests, please retry later",
+		domain.CodeBodyTooLarge:         "The request body is too large",
+		domain.CodeInvalidScope:         "The requested scope is not allowed",
+		domain.CodeInvalidTarget:        "The requested audience is not allowed",
+		domain.CodeInternalError:        "An internal error occurred",
+	},
+}
+
+// Message Accept-Language에 맞는 에러 메시지 (지원하지 않는 언어는 한국어)
+func Message(code, acceptLanguage string) string {
+	tags, _, _ := language.ParseAcceptLanguage(acceptLanguage)
+	_, index, _ := matcher.Match(tags...)
+
+	if msg, ok := messages[supportedLanguages[index]][code]; ok {
+		return msg
+	}
+	return messages[supportedLanguages[0]][domain.CodeInternalError]
+}
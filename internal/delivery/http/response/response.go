@@ -0,0 +1,13 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// JSON JSON 응답 작성
+func JSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/signalable/qauth/internal/caller"
+	"github.com/signalable/qauth/internal/delivery/http/handler"
+	"github.com/signalable/qauth/internal/delivery/http/middleware"
+)
+
+// SetupAPIKeyRoutes API 키 관리 라우터 설정
+func SetupAPIKeyRoutes(router *mux.Router, apiKeyHandler *handler.APIKeyHandler, callerAuthMiddleware *middleware.CallerAuthMiddleware) {
+	// 내부 운영 API (파트너 API 키 발급, 조회, 교체, 폐기)
+	router.HandleFunc("/api/admin/api-keys", callerAuthMiddleware.Require(caller.OpAdminAPIKeys, apiKeyHandler.Issue)).Methods("POST")
+	router.HandleFunc("/api/admin/api-keys", callerAuthMiddleware.Require(caller.OpAdminAPIKeys, apiKeyHandler.List)).Methods("GET")
+	router.HandleFunc("/api/admin/api-keys/{id}", callerAuthMiddleware.Require(caller.OpAdminAPIKeys, apiKeyHandler.Get)).Methods("GET")
+	router.HandleFunc("/api/admin/api-keys/{id}/rotate", callerAuthMiddleware.Require(caller.OpAdminAPIKeys, apiKeyHandler.Rotate)).Methods("POST")
+	router.HandleFunc("/api/admin/api-keys/{id}", callerAuthMiddleware.Require(caller.OpAdminAPIKeys, apiKeyHandler.Revoke)).Methods("DELETE")
+}
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/gorilla/mux"
+	"github.com/signalable/qauth/internal/caller"
 	"github.com/signalable/qauth/internal/delivery/http/handler"
 	"github.com/signalable/qauth/internal/delivery/http/middleware"
 )
@@ -11,12 +12,31 @@ func SetupAuthRoutes(
 	router *mux.Router,
 	authHandler *handler.AuthHandler,
 	authMiddleware *middleware.AuthMiddleware,
+	callerAuthMiddleware *middleware.CallerAuthMiddleware,
 ) {
-	// 내부 서비스 간 API (User Service에서 호출)
-	router.HandleFunc("/api/auth/token", authHandler.CreateToken).Methods("POST")
-	router.HandleFunc("/api/auth/token/validate", authHandler.ValidateToken).Methods("GET")
+	// 내부 서비스 간 API (등록된 호출자 중 해당 작업이 허용된 호출자만 사용 가능)
+	router.HandleFunc("/api/auth/token", callerAuthMiddleware.Require(caller.OpTokenCreate, authHandler.CreateToken)).Methods("POST")
+	router.HandleFunc("/api/auth/token/validate", callerAuthMiddleware.Require(caller.OpTokenValidate, authHandler.ValidateToken)).Methods("GET")
+	router.HandleFunc("/api/auth/token/status", callerAuthMiddleware.Require(caller.OpTokenValidate, authHandler.TokenStatus)).Methods("GET")
 
 	// 클라이언트 API
 	router.HandleFunc("/api/auth/token/refresh", authHandler.RefreshToken).Methods("POST")
 	router.HandleFunc("/api/auth/token/revoke", authMiddleware.Authenticate(authHandler.RevokeToken)).Methods("POST")
 }
+
+// SetupForwardAuthRoutes 프록시 위임 인증 라우터 설정
+//
+// 토큰 검증 결과를 응답하므로 token.validate가 허용된 호출자(프록시)만 사용할 수 있습니다.
+func SetupForwardAuthRoutes(
+	router *mux.Router,
+	forwardAuthHandler *handler.ForwardAuthHandler,
+	callerAuthMiddleware *middleware.CallerAuthMiddleware,
+) {
+	forwardAuth := callerAuthMiddleware.Require(caller.OpTokenValidate, forwardAuthHandler.ForwardAuth)
+
+	// nginx auth_request / Traefik ForwardAuth (원 요청 메서드와 무관하게 허용)
+	router.HandleFunc("/api/auth/forward", forwardAuth)
+
+	// Envoy ext_authz HTTP 모드 (path_prefix 뒤에 원 요청 경로가 붙어서 전달됨)
+	router.PathPrefix("/api/auth/ext_authz").HandlerFunc(forwardAuth)
+}
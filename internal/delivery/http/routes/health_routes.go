@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/signalable/qauth/internal/delivery/http/handler"
+)
+
+// SetupHealthRoutes 헬스 체크 라우터 설정
+func SetupHealthRoutes(router *mux.Router, healthHandler *handler.HealthHandler) {
+	// Kubernetes liveness / readiness probe
+	router.HandleFunc("/healthz", healthHandler.Liveness).Methods("GET")
+	router.HandleFunc("/readyz", healthHandler.Readiness).Methods("GET")
+}
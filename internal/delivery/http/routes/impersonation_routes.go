@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/signalable/qauth/internal/caller"
+	"github.com/signalable/qauth/internal/delivery/http/handler"
+	"github.com/signalable/qauth/internal/delivery/http/middleware"
+)
+
+// SetupImpersonationRoutes 관리자 대리 로그인 라우터 설정
+func SetupImpersonationRoutes(router *mux.Router, impersonationHandler *handler.ImpersonationHandler, callerAuthMiddleware *middleware.CallerAuthMiddleware) {
+	// 내부 운영 API (지원 담당자의 고객 대리 로그인 시작, 조회, 폐기)
+	router.HandleFunc("/api/admin/impersonations", callerAuthMiddleware.Require(caller.OpAdminImpersonation, impersonationHandler.Start)).Methods("POST")
+	router.HandleFunc("/api/admin/impersonations", callerAuthMiddleware.Require(caller.OpAdminImpersonation, impersonationHandler.List)).Methods("GET")
+	router.HandleFunc("/api/admin/impersonations/{id}", callerAuthMiddleware.Require(caller.OpAdminImpersonation, impersonationHandler.Get)).Methods("GET")
+	router.HandleFunc("/api/admin/impersonations/{id}", callerAuthMiddleware.Require(caller.OpAdminImpersonation, impersonationHandler.Revoke)).Methods("DELETE")
+}
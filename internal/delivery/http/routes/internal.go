@@ -0,0 +1,25 @@
+package routes
+
+// InternalPaths 내부 서비스 및 운영 전용 경로 (정확한 경로 또는 "*"로 끝나는 접두사)
+//
+// 브라우저에서 호출할 일이 없으므로 CORS 정책과 무관하게 출처가 붙은 요청은 거부됩니다.
+var InternalPaths = []string{
+	"/api/auth/token",
+	"/api/auth/token/validate",
+	"/api/auth/token/status",
+	"/api/auth/forward",
+	"/api/auth/ext_authz*",
+	"/api/auth/login/*",
+	"/api/admin/*",
+	"/metrics",
+}
+
+// AnonymousPaths 호출자/사용자 인증 미들웨어를 거치지 않는 경로 (정확한 경로 또는 "*"로 끝나는 접두사)
+//
+// 요청 제한의 user, client 키 한도는 이 경로에서 인증된 신원 대신 IP 기준으로 적용됩니다.
+var AnonymousPaths = []string{
+	"/api/auth/token/refresh",
+	"/healthz",
+	"/readyz",
+	"/.well-known/jwks.json",
+}
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/signalable/qauth/internal/delivery/http/handler"
+)
+
+// SetupKeyRoutes 공개키 배포 라우터 설정
+func SetupKeyRoutes(router *mux.Router, keysHandler *handler.KeysHandler) {
+	// 리소스 서버의 오프라인 토큰 검증용 JWKS
+	router.HandleFunc("/.well-known/jwks.json", keysHandler.JWKS).Methods("GET")
+}
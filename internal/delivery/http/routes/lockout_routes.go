@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/signalable/qauth/internal/caller"
+	"github.com/signalable/qauth/internal/delivery/http/handler"
+	"github.com/signalable/qauth/internal/delivery/http/middleware"
+)
+
+// SetupLockoutRoutes 로그인 잠금 라우터 설정
+func SetupLockoutRoutes(router *mux.Router, lockoutHandler *handler.LockoutHandler, callerAuthMiddleware *middleware.CallerAuthMiddleware) {
+	// User Service 로그인 흐름 (자격 증명 확인 전 check, 결과에 따라 failure 또는 success 보고)
+	router.HandleFunc("/api/auth/login/check", callerAuthMiddleware.Require(caller.OpLoginAttempts, lockoutHandler.CheckLogin)).Methods("POST")
+	router.HandleFunc("/api/auth/login/failure", callerAuthMiddleware.Require(caller.OpLoginAttempts, lockoutHandler.LoginFailed)).Methods("POST")
+	router.HandleFunc("/api/auth/login/success", callerAuthMiddleware.Require(caller.OpLoginAttempts, lockoutHandler.LoginSucceeded)).Methods("POST")
+
+	// 내부 운영 API (kind는 account 또는 ip)
+	router.HandleFunc("/api/admin/lockouts", callerAuthMiddleware.Require(caller.OpAdminLockouts, lockoutHandler.ListLocked)).Methods("GET")
+	router.HandleFunc("/api/admin/lockouts/{kind}/{id}", callerAuthMiddleware.Require(caller.OpAdminLockouts, lockoutHandler.Status)).Methods("GET")
+	router.HandleFunc("/api/admin/lockouts/{kind}/{id}", callerAuthMiddleware.Require(caller.OpAdminLockouts, lockoutHandler.Clear)).Methods("DELETE")
+}
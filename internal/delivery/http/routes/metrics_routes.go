@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/signalable/qauth/internal/caller"
+	"github.com/signalable/qauth/internal/delivery/http/middleware"
+)
+
+// SetupMetricsRoutes 기본 포트의 Prometheus 지표 라우터 설정 (metrics.read가 허용된 호출자만 조회)
+func SetupMetricsRoutes(router *mux.Router, metricsHandler http.Handler, callerAuthMiddleware *middleware.CallerAuthMiddleware) {
+	router.HandleFunc("/metrics", callerAuthMiddleware.Require(caller.OpMetricsRead, metricsHandler.ServeHTTP)).Methods("GET")
+}
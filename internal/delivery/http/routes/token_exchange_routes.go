@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/signalable/qauth/internal/caller"
+	"github.com/signalable/qauth/internal/delivery/http/handler"
+	"github.com/signalable/qauth/internal/delivery/http/middleware"
+)
+
+// SetupTokenExchangeRoutes 토큰 교환 라우터 설정
+//
+// 토큰 발급과 같은 주소를 form 본문으로 구분하므로 SetupAuthRoutes보다 먼저 등록해야 합니다.
+func SetupTokenExchangeRoutes(router *mux.Router, exchangeHandler *handler.TokenExchangeHandler, callerAuthMiddleware *middleware.CallerAuthMiddleware) {
+	// 내부 서비스 간 API (grant_type=urn:ietf:params:oauth:grant-type:token-exchange)
+	router.HandleFunc("/api/auth/token", callerAuthMiddleware.Require(caller.OpTokenExchange, exchangeHandler.ExchangeToken)).
+		Methods("POST").
+		HeadersRegexp("Content-Type", "^application/x-www-form-urlencoded")
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/signalable/qauth/internal/caller"
+	"github.com/signalable/qauth/internal/delivery/http/handler"
+	"github.com/signalable/qauth/internal/delivery/http/middleware"
+)
+
+// SetupWebhookRoutes 웹훅 관리 라우터 설정
+func SetupWebhookRoutes(router *mux.Router, webhookHandler *handler.WebhookHandler, callerAuthMiddleware *middleware.CallerAuthMiddleware) {
+	// 내부 운영 API (실패 전송 조회 및 재전송)
+	router.HandleFunc("/api/admin/webhooks/dead-letters", callerAuthMiddleware.Require(caller.OpAdminWebhooks, webhookHandler.ListDeadLetters)).Methods("GET")
+	router.HandleFunc("/api/admin/webhooks/dead-letters/{id}/replay", callerAuthMiddleware.Require(caller.OpAdminWebhooks, webhookHandler.Replay)).Methods("POST")
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// APIKey 파트너 연동용 장기 API 키 (비밀 값은 해시로만 보관)
+type APIKey struct {
+	// ID 키 앞부분에 그대로 노출되는 식별자 (키 원문 "qak_<ID>_<비밀 값>")
+	ID string `json:"id"`
+
+	// Prefix 로그나 설정 화면에서 키를 구분하는 공개 접두사 ("qak_<ID>")
+	Prefix string `json:"prefix"`
+
+	Name   string `json:"name"`
+	UserID string `json:"user_id"`
+
+	// Scopes 키로 허용할 권한 범위 (검증 응답으로 전달)
+	Scopes []string `json:"scopes,omitempty"`
+
+	// AllowedIPs 키를 사용할 수 있는 클라이언트 IP 또는 CIDR (비어 있으면 제한 없음)
+	AllowedIPs []string `json:"allowed_ips,omitempty"`
+
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// APIKeyRequest API 키 발급 요청 DTO
+type APIKeyRequest struct {
+	Name       string     `json:"name"`
+	UserID     string     `json:"user_id"`
+	Scopes     []string   `json:"scopes,omitempty"`
+	AllowedIPs []string   `json:"allowed_ips,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// IssuedAPIKey 발급 또는 교체된 API 키 (Key 원문은 이 응답에서만 확인 가능)
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type clientIPContextKey struct{}
+
+// WithClientIP context에 요청 클라이언트 IP 추가 (API 키 IP 제한 확인용)
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPContextKey{}, ip)
+}
+
+// ClientIPFromContext context에서 요청 클라이언트 IP 조회 (없으면 빈 문자열)
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPContextKey{}).(string)
+	return ip
+}
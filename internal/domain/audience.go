@@ -0,0 +1,16 @@
+package domain
+
+import "context"
+
+type audienceContextKey struct{}
+
+// WithExpectedAudience context에 검증할 토큰에 허용하는 aud 추가 (프록시 원 요청 경로별 규칙)
+func WithExpectedAudience(ctx context.Context, audiences []string) context.Context {
+	return context.WithValue(ctx, audienceContextKey{}, audiences)
+}
+
+// ExpectedAudienceFromContext context에서 허용하는 aud 조회 (없으면 false)
+func ExpectedAudienceFromContext(ctx context.Context) ([]string, bool) {
+	audiences, ok := ctx.Value(audienceContextKey{}).([]string)
+	return audiences, ok
+}
@@ -17,6 +17,40 @@ type AuthResponse struct {
 type TokenValidationResponse struct {
 	Valid  bool   `json:"valid"`
 	UserID string `json:"user_id,omitempty"`
+
+	// Confirmation 소유 증명이 확인된 키 (키에 바인딩된 토큰만)
+	Confirmation *Confirmation `json:"cnf,omitempty"`
+
+	// Scopes 자격 증명에 허용된 권한 범위 (API 키와 교환 토큰)
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Audience 토큰 사용 대상 (aud claim)
+	Audience []string `json:"aud,omitempty"`
+
+	// Actor 위임 토큰이면 사용자를 대신해 호출하는 주체
+	Actor *Actor `json:"act,omitempty"`
+
+	// Impersonation 관리자 대리 로그인 토큰 여부 (Actor가 관리자)
+	Impersonation bool `json:"impersonation,omitempty"`
+
+	// APIKeyID 검증한 자격 증명이 API 키면 키 ID
+	APIKeyID string `json:"api_key_id,omitempty"`
+}
+
+// TokenStatusResponse 토큰 상태 조회 응답 (키 바인딩의 소유 증명 없이 폐기 여부만 확인)
+type TokenStatusResponse struct {
+	Active    bool   `json:"active"`
+	UserID    string `json:"user_id,omitempty"`
+	ExpiresAt int64  `json:"expires_at,omitempty"`
+}
+
+// Confirmation 토큰이 바인딩된 키 (RFC 7800 cnf claim)
+type Confirmation struct {
+	// JKT DPoP 공개키의 JWK SHA-256 thumbprint (RFC 9449)
+	JKT string `json:"jkt,omitempty"`
+
+	// X5T 클라이언트 인증서의 SHA-256 thumbprint (RFC 8705)
+	X5T string `json:"x5t#S256,omitempty"`
 }
 
 // TokenMetadata 토큰 메타데이터
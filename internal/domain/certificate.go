@@ -0,0 +1,16 @@
+package domain
+
+import "context"
+
+type certificateContextKey struct{}
+
+// WithCertificateThumbprint context에 요청 클라이언트 인증서의 SHA-256 thumbprint 추가
+func WithCertificateThumbprint(ctx context.Context, thumbprint string) context.Context {
+	return context.WithValue(ctx, certificateContextKey{}, thumbprint)
+}
+
+// CertificateThumbprintFromContext context에서 클라이언트 인증서 thumbprint 조회 (없으면 빈 문자열)
+func CertificateThumbprintFromContext(ctx context.Context) string {
+	thumbprint, _ := ctx.Value(certificateContextKey{}).(string)
+	return thumbprint
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "context"
+
+// ProofRequest 요청에 첨부된 DPoP 증명과 증명 대상 요청 (RFC 9449)
+type ProofRequest struct {
+	// Proof DPoP 헤더 값 (증명 JWT)
+	Proof string
+
+	// Method, URL 증명의 htm/htu와 비교할 요청 메서드와 주소
+	Method string
+	URL    string
+
+	// Forwarded 프록시나 내부 호출자가 전달한 원 요청 정보인지 여부
+	// (false면 qauth가 직접 받은 요청이므로 설정된 외부 주소 기준으로 htu 비교)
+	Forwarded bool
+
+	// Scheme 토큰을 DPoP Authorization scheme으로 제시했는지 여부
+	// (true면 DPoP 바인딩이 없는 토큰은 거부, RFC 9449 7.1)
+	Scheme bool
+}
+
+type proofContextKey struct{}
+
+// WithProof context에 DPoP 증명 추가
+func WithProof(ctx context.Context, proof ProofRequest) context.Context {
+	return context.WithValue(ctx, proofContextKey{}, proof)
+}
+
+// ProofFromContext context에서 DPoP 증명 조회 (없으면 false)
+func ProofFromContext(ctx context.Context) (ProofRequest, bool) {
+	proof, ok := ctx.Value(proofContextKey{}).(ProofRequest)
+	return proof, ok && proof.Proof != ""
+}
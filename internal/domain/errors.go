@@ -8,8 +8,78 @@ var (
 	ErrExpiredToken = errors.New("만료된 토큰입니다")
 	ErrRevokedToken = errors.New("폐기된 토큰입니다")
 
+	// DPoP 증명 관련 에러
+	ErrInvalidDPoPProof = errors.New("유효하지 않은 DPoP 증명입니다")
+
 	// 인증 관련 에러
 	ErrAuthenticationFailed = errors.New("인증에 실패했습니다")
 	ErrUnauthorized         = errors.New("권한이 없습니다")
+	ErrOriginNotAllowed     = errors.New("허용되지 않은 출처입니다")
 	ErrInvalidCredentials   = errors.New("잘못된 인증 정보입니다")
+
+	// 요청 관련 에러
+	ErrMissingToken   = errors.New("토큰이 필요합니다")
+	ErrInvalidRequest = errors.New("잘못된 요청입니다")
+	ErrNotFound       = errors.New("리소스를 찾을 수 없습니다")
+	ErrRateLimited    = errors.New("요청이 너무 많습니다")
+	ErrBodyTooLarge   = errors.New("요청 본문이 너무 큽니다")
+
+	// 토큰 교환 관련 에러 (RFC 8693 2.2.2)
+	ErrInvalidScope  = errors.New("허용되지 않은 scope입니다")
+	ErrInvalidTarget = errors.New("허용되지 않은 대상입니다")
 )
+
+// 에러 코드 (API 응답, 지표 라벨, 감사 로그에서 공통으로 쓰는 안정적인 값)
+const (
+	CodeInvalidRequest       = "invalid_request"
+	CodeMissingToken         = "missing_token"
+	CodeInvalidToken         = "invalid_token"
+	CodeExpiredToken         = "expired_token"
+	CodeRevokedToken         = "revoked_token"
+	CodeInvalidDPoPProof     = "invalid_dpop_proof"
+	CodeAuthenticationFailed = "authentication_failed"
+	CodeInvalidCredentials   = "invalid_credentials"
+	CodeForbidden            = "forbidden"
+	CodeOriginNotAllowed     = "origin_not_allowed"
+	CodeNotFound             = "not_found"
+	CodeRateLimited          = "rate_limited"
+	CodeBodyTooLarge         = "body_too_large"
+	CodeInvalidScope         = "invalid_scope"
+	CodeInvalidTarget        = "invalid_target"
+	CodeInternalError        = "internal_error"
+)
+
+// errorCodes 에러별 코드 (감싼 에러도 찾도록 위에서부터 errors.Is로 비교)
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{ErrMissingToken, CodeMissingToken},
+	{ErrInvalidRequest, CodeInvalidRequest},
+	{ErrExpiredToken, CodeExpiredToken},
+	{ErrRevokedToken, CodeRevokedToken},
+	{ErrInvalidDPoPProof, CodeInvalidDPoPProof},
+	{ErrInvalidToken, CodeInvalidToken},
+	{ErrAuthenticationFailed, CodeAuthenticationFailed},
+	{ErrInvalidCredentials, CodeInvalidCredentials},
+	{ErrUnauthorized, CodeForbidden},
+	{ErrOriginNotAllowed, CodeOriginNotAllowed},
+	{ErrNotFound, CodeNotFound},
+	{ErrRateLimited, CodeRateLimited},
+	{ErrBodyTooLarge, CodeBodyTooLarge},
+	{ErrInvalidScope, CodeInvalidScope},
+	{ErrInvalidTarget, CodeInvalidTarget},
+}
+
+// ErrorCode 에러에 대응하는 코드 (nil이면 빈 문자열, domain 에러가 아니면 internal_error)
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	for _, e := range errorCodes {
+		if errors.Is(err, e.err) {
+			return e.code
+		}
+	}
+	return CodeInternalError
+}
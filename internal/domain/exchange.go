@@ -0,0 +1,49 @@
+package domain
+
+// 토큰 교환 grant와 토큰 종류 식별자 (RFC 8693 3)
+const (
+	GrantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	TokenTypeAccessToken   = "urn:ietf:params:oauth:token-type:access_token"
+	TokenTypeJWT           = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+// TokenExchangeRequest 토큰 교환 요청 DTO (RFC 8693 2.1)
+type TokenExchangeRequest struct {
+	SubjectToken     string
+	SubjectTokenType string
+
+	// ActorToken 사용자를 대신해 호출하는 서비스의 토큰 (없으면 인증된 내부 호출자가 actor)
+	ActorToken     string
+	ActorTokenType string
+
+	// Audience 교환 토큰을 사용할 대상 서비스
+	Audience string
+
+	// Scopes 요청 scope (비어 있으면 허용 범위 전체)
+	Scopes []string
+
+	RequestedTokenType string
+}
+
+// TokenExchangeResponse 토큰 교환 응답 DTO (RFC 8693 2.2.1)
+type TokenExchangeResponse struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int64  `json:"expires_in"`
+	Scope           string `json:"scope,omitempty"`
+}
+
+// Actor 위임 토큰에서 사용자를 대신해 호출하는 주체 (RFC 8693 4.1 act claim, 이전 actor는 중첩)
+type Actor struct {
+	Subject string `json:"sub"`
+	Actor   *Actor `json:"act,omitempty"`
+}
+
+// Initiator 위임 체인에서 처음 위임받은 주체 (중첩된 act의 가장 안쪽 sub)
+func (a *Actor) Initiator() string {
+	for a.Actor != nil {
+		a = a.Actor
+	}
+	return a.Subject
+}
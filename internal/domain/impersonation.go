@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// Impersonation 관리자의 고객 대리 로그인 세션 (고객 본인의 세션과 별도로 폐기 가능)
+type Impersonation struct {
+	// ID 대리 로그인 토큰의 jti
+	ID string `json:"id"`
+
+	// UserID 대리 로그인 대상 고객
+	UserID string `json:"user_id"`
+
+	// AdminID 대리 로그인한 관리자 (검증된 관리자 토큰의 사용자, 토큰의 act.sub)
+	AdminID string `json:"admin_id"`
+
+	// CallerID 대리 로그인을 요청한 내부 호출자 (관리 콘솔 등)
+	CallerID string `json:"caller_id"`
+
+	// Reason 대리 로그인 사유 (지원 티켓 번호 등)
+	Reason string `json:"reason"`
+
+	Scopes    []string   `json:"scopes"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// ImpersonationRequest 대리 로그인 시작 요청 DTO
+type ImpersonationRequest struct {
+	UserID string `json:"user_id"`
+
+	// AdminID 생략 가능 (있으면 관리자 토큰의 사용자와 같아야 함)
+	AdminID string `json:"admin_id,omitempty"`
+
+	// AdminToken 대리 로그인하는 관리자 본인의 액세스 토큰 (본문이 아닌 X-QAuth-Admin-Token 헤더로 전달)
+	AdminToken string `json:"-"`
+
+	Reason string `json:"reason"`
+
+	// Scopes 요청 scope (비어 있으면 대리 로그인에 허용된 scope 전체)
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Duration 유효 시간 (예: "30m", 비어 있으면 기본값)
+	Duration string `json:"duration,omitempty"`
+}
+
+// IssuedImpersonation 대리 로그인 시작 응답 (AccessToken은 이 응답에서만 확인 가능)
+type IssuedImpersonation struct {
+	Impersonation
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// ClaimImpersonation 대리 로그인 토큰 표시 claim (값 true, 이 토큰을 교환한 위임 토큰에도 유지)
+const ClaimImpersonation = "impersonation"
+
+// Impersonator 대리 로그인 토큰이면 대리 로그인한 관리자 ID (교환된 토큰이면 위임 체인의 처음 actor, 아니면 빈 값)
+func (r *TokenValidationResponse) Impersonator() string {
+	if !r.Impersonation || r.Actor == nil {
+		return ""
+	}
+	return r.Actor.Initiator()
+}
@@ -0,0 +1,17 @@
+package domain
+
+// LoginAttempt User Service가 보고하는 로그인 시도 DTO
+type LoginAttempt struct {
+	// Account 로그인 계정 식별자 (이메일 등)
+	Account string `json:"account"`
+
+	// IP 로그인을 요청한 최종 사용자 IP (User Service가 받은 클라이언트 주소)
+	IP string `json:"ip,omitempty"`
+}
+
+// LoginCheckResponse 로그인 시도 허용 여부 응답 DTO
+//
+// 잠겼거나 대기 시간 중인 이유는 알려주지 않으므로 User Service는 일반 로그인 실패로 응답해야 합니다.
+type LoginCheckResponse struct {
+	Allowed bool `json:"allowed"`
+}
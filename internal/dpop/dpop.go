@@ -0,0 +1,235 @@
+package dpop
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/signalable/qauth/internal/config"
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/pkg/jwt"
+)
+
+// proofType 증명 JWT의 typ 헤더
+const proofType = "dpop+jwt"
+
+// allowedAlgorithms 증명 서명에 허용하는 비대칭 알고리즘 (HS*/none은 키 소유 증명이 되지 않음)
+var allowedAlgorithms = map[string]bool{
+	"RS256": true, "RS384": true, "RS512": true,
+	"ES256": true, "ES384": true, "ES512": true,
+	"EdDSA": true,
+}
+
+// privateMembers 공개키 JWK에 있으면 안 되는 비밀 멤버 (RFC 9449 4.3)
+var privateMembers = []string{"d", "p", "q", "dp", "dq", "qi", "oth", "k"}
+
+// Verifier DPoP 증명(RFC 9449) 검증기
+//
+// 증명의 서명, typ/alg, htm/htu, iat, ath를 확인하고 jti를 Redis에 기록해
+// 여러 인스턴스에서 같은 증명을 다시 쓸 수 없게 합니다.
+type Verifier struct {
+	client *redis.Client
+	cfg    atomic.Pointer[config.DPoPConfig]
+
+	// now 현재 시각 (테스트에서 교체)
+	now func() time.Time
+}
+
+// NewVerifier DPoP 증명 검증기 생성자
+func NewVerifier(client *redis.Client, cfg config.DPoPConfig) *Verifier {
+	v := &Verifier{
+		client: client,
+		now:    time.Now,
+	}
+	v.cfg.Store(&cfg)
+	return v
+}
+
+// Prepare 새 설정 검증 후 적용 함수 반환 (hot reload용)
+func (v *Verifier) Prepare(cfg config.DPoPConfig) (func(), error) {
+	if cfg.ProofMaxAge <= 0 {
+		return nil, fmt.Errorf("증명 허용 시간이 올바르지 않습니다: %s", cfg.ProofMaxAge)
+	}
+	if cfg.BaseURL != "" {
+		if _, err := url.Parse(cfg.BaseURL); err != nil {
+			return nil, fmt.Errorf("외부 주소 파싱 실패: %w", err)
+		}
+	}
+	return func() { v.cfg.Store(&cfg) }, nil
+}
+
+// Verify 증명을 검증하고 증명 키의 JWK SHA-256 thumbprint 반환
+//
+// accessToken이 있으면 증명의 ath claim이 토큰 해시와 일치해야 합니다.
+func (v *Verifier) Verify(ctx context.Context, proof domain.ProofRequest, accessToken string) (string, error) {
+	cfg := v.cfg.Load()
+
+	header, _, err := jwt.DecodeToken(proof.Proof)
+	if err != nil {
+		return "", invalid("형식 오류")
+	}
+	if typ, _ := header["typ"].(string); !strings.EqualFold(typ, proofType) {
+		return "", invalid("typ 불일치")
+	}
+	if alg, _ := header["alg"].(string); !allowedAlgorithms[alg] {
+		return "", invalid("허용되지 않은 alg")
+	}
+
+	jwk, err := publicJWK(header["jwk"])
+	if err != nil {
+		return "", invalid(err.Error())
+	}
+	key, err := jwk.PublicKey()
+	if err != nil {
+		return "", invalid("jwk 공개키 오류")
+	}
+	thumbprint, err := jwk.Thumbprint()
+	if err != nil {
+		return "", invalid("jwk thumbprint 계산 불가")
+	}
+
+	// 서명 검증 (키 종류와 alg가 맞지 않으면 Parse가 거부)
+	claims, err := jwt.Parse(proof.Proof, func(string, string) (interface{}, error) {
+		return key, nil
+	})
+	if err != nil {
+		return "", invalid("서명 검증 실패")
+	}
+
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return "", invalid("jti 없음")
+	}
+	if htm, _ := claims["htm"].(string); htm != proof.Method {
+		return "", invalid("htm 불일치")
+	}
+	htu, _ := claims["htu"].(string)
+	if !sameURL(htu, v.target(cfg, proof)) {
+		return "", invalid("htu 불일치")
+	}
+
+	iat, ok := claims["iat"].(float64)
+	if !ok {
+		return "", invalid("iat 없음")
+	}
+	if skew := v.now().Sub(time.Unix(int64(iat), 0)); skew > cfg.ProofMaxAge || skew < -cfg.ProofMaxAge {
+		return "", invalid("iat 허용 범위 초과")
+	}
+
+	if accessToken != "" {
+		if ath, _ := claims["ath"].(string); ath != TokenHash(accessToken) {
+			return "", invalid("ath 불일치")
+		}
+	}
+
+	// 같은 키의 같은 jti는 iat 허용 범위 전체(앞뒤) 동안 한 번만 사용 가능
+	jtiHash := sha256.Sum256([]byte(jti))
+	first, err := v.client.SetNX(ctx, "dpop:jti:"+thumbprint+":"+base64.RawURLEncoding.EncodeToString(jtiHash[:]), 1, 2*cfg.ProofMaxAge).Result()
+	if err != nil {
+		return "", fmt.Errorf("DPoP jti 기록 실패: %w", err)
+	}
+	if !first {
+		return "", invalid("재사용된 jti")
+	}
+
+	return thumbprint, nil
+}
+
+// TokenHash 증명의 ath claim 값 (액세스 토큰 SHA-256의 base64url)
+func TokenHash(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// target 증명의 htu와 비교할 주소
+//
+// qauth가 직접 받은 요청은 외부 주소가 설정되어 있으면 그 주소에 요청 경로를 붙여 사용합니다.
+func (v *Verifier) target(cfg *config.DPoPConfig, proof domain.ProofRequest) string {
+	if proof.Forwarded || cfg.BaseURL == "" {
+		return proof.URL
+	}
+	u, err := url.Parse(proof.URL)
+	if err != nil {
+		return proof.URL
+	}
+	return strings.TrimRight(cfg.BaseURL, "/") + u.EscapedPath()
+}
+
+// publicJWK 증명 헤더의 jwk를 공개키 JWK로 변환 (비밀 멤버가 있으면 거부)
+func publicJWK(raw interface{}) (jwt.JWK, error) {
+	members, ok := raw.(map[string]interface{})
+	if !ok {
+		return jwt.JWK{}, errors.New("jwk 없음")
+	}
+	for _, name := range privateMembers {
+		if _, exists := members[name]; exists {
+			return jwt.JWK{}, errors.New("jwk에 비밀 키 포함")
+		}
+	}
+
+	data, err := json.Marshal(members)
+	if err != nil {
+		return jwt.JWK{}, errors.New("jwk 형식 오류")
+	}
+	var jwk jwt.JWK
+	if err := json.Unmarshal(data, &jwk); err != nil {
+		return jwt.JWK{}, errors.New("jwk 형식 오류")
+	}
+	return jwk, nil
+}
+
+// sameURL htu 비교 (RFC 9449 4.3: query와 fragment 제외, scheme/host 대소문자와 기본 포트 정규화)
+func sameURL(a, b string) bool {
+	ua, err := normalizeURL(a)
+	if err != nil {
+		return false
+	}
+	ub, err := normalizeURL(b)
+	if err != nil {
+		return false
+	}
+	return ua == ub
+}
+
+func normalizeURL(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("절대 주소가 아닙니다: %q", raw)
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if (scheme == "https" && port == "443") || (scheme == "http" && port == "80") {
+		port = ""
+	}
+	if port != "" {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	return scheme + "://" + host + path, nil
+}
+
+// invalid 증명 검증 실패 에러
+func invalid(reason string) error {
+	return fmt.Errorf("%w: %s", domain.ErrInvalidDPoPProof, reason)
+}
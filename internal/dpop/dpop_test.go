@@ -0,0 +1,255 @@
+package dpop
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	gjwt "github.com/golang-jwt/jwt"
+
+	"github.com/signalable/qauth/internal/config"
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/pkg/jwt"
+)
+
+const (
+	testURL   = "https://api.example.com/resource"
+	testToken = "access-token"
+)
+
+// testNow 테스트 기준 시각
+var testNow = time.Unix(1700000000, 0)
+
+// proofSigner 테스트용 증명 서명 키
+type proofSigner struct {
+	priv *ecdsa.PrivateKey
+	jwk  map[string]interface{}
+}
+
+func newProofSigner(t *testing.T) *proofSigner {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwk, err := jwt.NewJWK(&priv.PublicKey, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwk.Use, jwk.Alg = "", ""
+	data, _ := json.Marshal(jwk)
+	var members map[string]interface{}
+	if err := json.Unmarshal(data, &members); err != nil {
+		t.Fatal(err)
+	}
+	return &proofSigner{priv: priv, jwk: members}
+}
+
+func (s *proofSigner) thumbprint(t *testing.T) string {
+	t.Helper()
+	jwk, err := publicJWK(s.jwk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	thumbprint, err := jwk.Thumbprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return thumbprint
+}
+
+// sign 기본 claims에 modify를 적용한 증명 생성
+func (s *proofSigner) sign(t *testing.T, modify func(header, claims map[string]interface{})) string {
+	t.Helper()
+	claims := gjwt.MapClaims{
+		"jti": "jti-1",
+		"htm": "GET",
+		"htu": testURL,
+		"iat": testNow.Unix(),
+		"ath": TokenHash(testToken),
+	}
+	token := gjwt.NewWithClaims(gjwt.SigningMethodES256, claims)
+	token.Header["typ"] = proofType
+	token.Header["jwk"] = s.jwk
+	if modify != nil {
+		modify(token.Header, claims)
+	}
+	proof, err := token.SignedString(s.priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return proof
+}
+
+func newTestVerifier(t *testing.T) *Verifier {
+	t.Helper()
+	client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	v := NewVerifier(client, config.DPoPConfig{ProofMaxAge: time.Minute})
+	v.now = func() time.Time { return testNow }
+	return v
+}
+
+func TestVerify(t *testing.T) {
+	signer := newProofSigner(t)
+
+	tests := []struct {
+		name   string
+		modify func(header, claims map[string]interface{})
+		method string
+		url    string
+		token  string
+		want   error
+	}{
+		{name: "유효한 증명"},
+		{name: "토큰 없이 발급 요청", token: "-"},
+		{name: "query와 기본 포트 무시", url: "https://API.example.com:443/resource?x=1"},
+		{
+			name:   "htm 불일치",
+			method: "POST",
+			want:   domain.ErrInvalidDPoPProof,
+		},
+		{
+			name: "htu 불일치",
+			url:  "https://api.example.com/other",
+			want: domain.ErrInvalidDPoPProof,
+		},
+		{
+			name: "오래된 iat",
+			modify: func(_, claims map[string]interface{}) {
+				claims["iat"] = testNow.Add(-2 * time.Minute).Unix()
+			},
+			want: domain.ErrInvalidDPoPProof,
+		},
+		{
+			name: "미래의 iat",
+			modify: func(_, claims map[string]interface{}) {
+				claims["iat"] = testNow.Add(2 * time.Minute).Unix()
+			},
+			want: domain.ErrInvalidDPoPProof,
+		},
+		{
+			name: "iat 없음",
+			modify: func(_, claims map[string]interface{}) {
+				delete(claims, "iat")
+			},
+			want: domain.ErrInvalidDPoPProof,
+		},
+		{
+			name: "ath 불일치",
+			modify: func(_, claims map[string]interface{}) {
+				claims["ath"] = TokenHash("other-token")
+			},
+			want: domain.ErrInvalidDPoPProof,
+		},
+		{
+			name: "jti 없음",
+			modify: func(_, claims map[string]interface{}) {
+				delete(claims, "jti")
+			},
+			want: domain.ErrInvalidDPoPProof,
+		},
+		{
+			name: "typ 불일치",
+			modify: func(header, _ map[string]interface{}) {
+				header["typ"] = "JWT"
+			},
+			want: domain.ErrInvalidDPoPProof,
+		},
+		{
+			name: "jwk에 비밀 키 포함",
+			modify: func(header, _ map[string]interface{}) {
+				jwk := map[string]interface{}{"d": "secret"}
+				for k, v := range signer.jwk {
+					jwk[k] = v
+				}
+				header["jwk"] = jwk
+			},
+			want: domain.ErrInvalidDPoPProof,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newTestVerifier(t)
+			proof := domain.ProofRequest{
+				Proof:  signer.sign(t, tt.modify),
+				Method: "GET",
+				URL:    testURL,
+			}
+			if tt.method != "" {
+				proof.Method = tt.method
+			}
+			if tt.url != "" {
+				proof.URL = tt.url
+			}
+			token := testToken
+			if tt.token == "-" {
+				token = ""
+			}
+
+			jkt, err := v.Verify(context.Background(), proof, token)
+			if tt.want != nil {
+				if !errors.Is(err, tt.want) {
+					t.Fatalf("err = %v, want %v", err, tt.want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if jkt != signer.thumbprint(t) {
+				t.Errorf("thumbprint = %q, want %q", jkt, signer.thumbprint(t))
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsReplayedJTI(t *testing.T) {
+	v := newTestVerifier(t)
+	signer := newProofSigner(t)
+	proof := domain.ProofRequest{Proof: signer.sign(t, nil), Method: "GET", URL: testURL}
+
+	if _, err := v.Verify(context.Background(), proof, testToken); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.Verify(context.Background(), proof, testToken); !errors.Is(err, domain.ErrInvalidDPoPProof) {
+		t.Fatalf("replay err = %v, want %v", err, domain.ErrInvalidDPoPProof)
+	}
+
+	// 같은 jti라도 다른 키의 증명은 별개
+	other := domain.ProofRequest{Proof: newProofSigner(t).sign(t, nil), Method: "GET", URL: testURL}
+	if _, err := v.Verify(context.Background(), other, testToken); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyUsesBaseURLForDirectRequests(t *testing.T) {
+	v := newTestVerifier(t)
+	apply, err := v.Prepare(config.DPoPConfig{ProofMaxAge: time.Minute, BaseURL: "https://api.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	apply()
+
+	signer := newProofSigner(t)
+	direct := domain.ProofRequest{Proof: signer.sign(t, nil), Method: "GET", URL: "http://10.0.0.5:8080/resource"}
+	if _, err := v.Verify(context.Background(), direct, testToken); err != nil {
+		t.Fatal(err)
+	}
+
+	forwarded := domain.ProofRequest{
+		Proof:     signer.sign(t, func(_, claims map[string]interface{}) { claims["jti"] = "jti-2" }),
+		Method:    "GET",
+		URL:       "http://10.0.0.5:8080/resource",
+		Forwarded: true,
+	}
+	if _, err := v.Verify(context.Background(), forwarded, testToken); !errors.Is(err, domain.ErrInvalidDPoPProof) {
+		t.Fatalf("forwarded err = %v, want %v", err, domain.ErrInvalidDPoPProof)
+	}
+}
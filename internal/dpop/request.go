@@ -0,0 +1,84 @@
+package dpop
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/signalable/qauth/internal/domain"
+)
+
+// Header DPoP 증명 헤더
+const Header = "DPoP"
+
+// Scheme DPoP 바인딩 토큰의 Authorization scheme
+const Scheme = "DPoP"
+
+// 프록시나 내부 호출자가 원 요청 정보를 전달하는 헤더 (Traefik ForwardAuth와 같은 이름)
+const (
+	HeaderForwardedMethod = "X-Forwarded-Method"
+	HeaderForwardedProto  = "X-Forwarded-Proto"
+	HeaderForwardedHost   = "X-Forwarded-Host"
+	HeaderForwardedURI    = "X-Forwarded-Uri"
+)
+
+// Attach 요청의 DPoP 증명을 context에 추가
+//
+// Authorization scheme이 DPoP이거나, Authorization 없이 DPoP 헤더만 있는 토큰 발급 요청일 때만
+// 증명을 추가합니다. Bearer scheme으로 보낸 요청은 DPoP 헤더가 있어도 무시하므로 바인딩된 토큰은
+// 검증에 실패합니다 (RFC 9449 7.2). forwarded가 true면 X-Forwarded-* 헤더의 원 요청을 증명 대상으로 봅니다.
+// 증명 헤더가 없거나 여러 개면 원래 요청과 함께 에러를 반환합니다.
+func Attach(r *http.Request, forwarded bool) (*http.Request, error) {
+	scheme, _, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	proofs := r.Header.Values(Header)
+
+	switch {
+	case strings.EqualFold(scheme, Scheme):
+	case scheme == "" && len(proofs) > 0:
+	default:
+		return r, nil
+	}
+	if len(proofs) != 1 || proofs[0] == "" {
+		return r, domain.ErrInvalidDPoPProof
+	}
+
+	proof := domain.ProofRequest{
+		Proof:     proofs[0],
+		Method:    r.Method,
+		URL:       requestURL(r),
+		Forwarded: forwarded,
+		Scheme:    scheme != "",
+	}
+	if forwarded {
+		proof.Method = headerOr(r, HeaderForwardedMethod, proof.Method)
+		proof.URL = forwardedURL(r)
+	}
+	return r.WithContext(domain.WithProof(r.Context(), proof)), nil
+}
+
+// requestURL qauth가 직접 받은 요청의 주소
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + r.URL.EscapedPath()
+}
+
+// forwardedURL X-Forwarded-* 헤더로 구성한 원 요청 주소 (없는 값은 현재 요청 기준)
+func forwardedURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	scheme = headerOr(r, HeaderForwardedProto, scheme)
+	host := headerOr(r, HeaderForwardedHost, r.Host)
+	uri := headerOr(r, HeaderForwardedURI, r.URL.EscapedPath())
+	return scheme + "://" + host + uri
+}
+
+func headerOr(r *http.Request, key, fallback string) string {
+	if value := r.Header.Get(key); value != "" {
+		return value
+	}
+	return fallback
+}
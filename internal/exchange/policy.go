@@ -0,0 +1,88 @@
+package exchange
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/signalable/qauth/internal/config"
+	"github.com/signalable/qauth/internal/domain"
+)
+
+// Policy 토큰 교환 대상(audience)과 대상별 scope 축소 규칙 (RFC 8693)
+//
+// 교환 토큰의 scope는 대상에 허용된 scope, subject 토큰의 scope(있으면), 요청 scope(있으면)의
+// 교집합이며 어느 한쪽이라도 넓힐 수는 없습니다.
+type Policy struct {
+	cfg atomic.Pointer[config.TokenExchangeConfig]
+}
+
+// NewPolicy 토큰 교환 정책 생성자
+func NewPolicy(cfg config.TokenExchangeConfig) *Policy {
+	p := &Policy{}
+	p.cfg.Store(&cfg)
+	return p
+}
+
+// Prepare 새 정책으로 교체하는 함수 반환 (reload 적용용, 설정은 이미 검증된 상태)
+func (p *Policy) Prepare(cfg config.TokenExchangeConfig) (func(), error) {
+	return func() { p.cfg.Store(&cfg) }, nil
+}
+
+// Grant 대상에 발급할 scope와 유효 시간 결정
+//
+// subjectScopes가 nil이면 subject 토큰에 scope 제한이 없는 것으로 보고, requested가 비어 있으면
+// 허용 범위 전체를 발급합니다. 등록되지 않은 대상은 ErrInvalidTarget, 허용 범위를 벗어난 요청 scope나
+// 발급할 scope가 없으면 ErrInvalidScope를 반환합니다.
+func (p *Policy) Grant(audience string, subjectScopes, requested []string) ([]string, time.Duration, error) {
+	cfg := p.cfg.Load()
+
+	var allowed []string
+	found := false
+	for _, target := range cfg.Audiences {
+		if target.Name == audience {
+			allowed, found = target.Scopes, true
+			break
+		}
+	}
+	if !found {
+		return nil, 0, fmt.Errorf("%w: %q", domain.ErrInvalidTarget, audience)
+	}
+
+	if subjectScopes != nil {
+		allowed = intersect(allowed, subjectScopes)
+	}
+	if len(requested) > 0 {
+		for _, scope := range requested {
+			if !contains(allowed, scope) {
+				return nil, 0, fmt.Errorf("%w: %q", domain.ErrInvalidScope, scope)
+			}
+		}
+		allowed = intersect(allowed, requested)
+	}
+	if len(allowed) == 0 {
+		return nil, 0, fmt.Errorf("%w: 발급할 수 있는 scope가 없습니다", domain.ErrInvalidScope)
+	}
+
+	return allowed, cfg.TokenTTL, nil
+}
+
+// intersect a의 순서를 유지한 교집합
+func intersect(a, b []string) []string {
+	result := []string{}
+	for _, v := range a {
+		if contains(b, v) && !contains(result, v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
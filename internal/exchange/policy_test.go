@@ -0,0 +1,141 @@
+package exchange
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/signalable/qauth/internal/config"
+	"github.com/signalable/qauth/internal/domain"
+)
+
+func testPolicy() *Policy {
+	return NewPolicy(config.TokenExchangeConfig{
+		TokenTTL: 5 * time.Minute,
+		Audiences: []config.ExchangeAudience{
+			{Name: "orders", Scopes: []string{"orders:read", "orders:write", "orders:cancel"}},
+			{Name: "billing", Scopes: []string{"billing:read"}},
+		},
+	})
+}
+
+func TestPolicyGrant(t *testing.T) {
+	tests := []struct {
+		name          string
+		audience      string
+		subjectScopes []string
+		requested     []string
+		want          []string
+		wantErr       error
+	}{
+		{
+			name:     "제한 없는 subject는 대상 허용 범위 전체",
+			audience: "orders",
+			want:     []string{"orders:read", "orders:write", "orders:cancel"},
+		},
+		{
+			name:      "요청 scope로 축소",
+			audience:  "orders",
+			requested: []string{"orders:write", "orders:read"},
+			want:      []string{"orders:read", "orders:write"},
+		},
+		{
+			name:          "subject scope로 축소",
+			audience:      "orders",
+			subjectScopes: []string{"orders:read", "profile:read"},
+			want:          []string{"orders:read"},
+		},
+		{
+			name:          "subject와 요청 scope의 교집합",
+			audience:      "orders",
+			subjectScopes: []string{"orders:read", "orders:write"},
+			requested:     []string{"orders:write"},
+			want:          []string{"orders:write"},
+		},
+		{
+			name:      "중복 요청 scope",
+			audience:  "orders",
+			requested: []string{"orders:read", "orders:read"},
+			want:      []string{"orders:read"},
+		},
+		{
+			name:     "등록되지 않은 대상",
+			audience: "unknown",
+			wantErr:  domain.ErrInvalidTarget,
+		},
+		{
+			name:      "대상 허용 범위 밖의 요청 scope",
+			audience:  "billing",
+			requested: []string{"billing:write"},
+			wantErr:   domain.ErrInvalidScope,
+		},
+		{
+			name:          "subject 범위를 넓히는 요청 scope",
+			audience:      "orders",
+			subjectScopes: []string{"orders:read"},
+			requested:     []string{"orders:write"},
+			wantErr:       domain.ErrInvalidScope,
+		},
+		{
+			name:          "발급할 scope 없음",
+			audience:      "billing",
+			subjectScopes: []string{"orders:read"},
+			wantErr:       domain.ErrInvalidScope,
+		},
+		{
+			name:          "scope 없는 subject",
+			audience:      "orders",
+			subjectScopes: []string{},
+			wantErr:       domain.ErrInvalidScope,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scopes, ttl, err := testPolicy().Grant(tt.audience, tt.subjectScopes, tt.requested)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(scopes, tt.want) {
+				t.Errorf("scopes = %v, want %v", scopes, tt.want)
+			}
+			if ttl != 5*time.Minute {
+				t.Errorf("ttl = %s, want %s", ttl, 5*time.Minute)
+			}
+		})
+	}
+}
+
+func TestPolicyPrepare(t *testing.T) {
+	p := testPolicy()
+	apply, err := p.Prepare(config.TokenExchangeConfig{
+		TokenTTL:  time.Minute,
+		Audiences: []config.ExchangeAudience{{Name: "billing", Scopes: []string{"billing:read", "billing:write"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 적용 전에는 이전 정책 유지
+	if _, _, err := p.Grant("orders", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	apply()
+
+	if _, _, err := p.Grant("orders", nil, nil); !errors.Is(err, domain.ErrInvalidTarget) {
+		t.Fatalf("removed audience err = %v, want %v", err, domain.ErrInvalidTarget)
+	}
+	scopes, ttl, err := p.Grant("billing", nil, []string{"billing:write"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(scopes, []string{"billing:write"}) || ttl != time.Minute {
+		t.Errorf("scopes = %v, ttl = %s", scopes, ttl)
+	}
+}
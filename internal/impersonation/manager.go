@@ -0,0 +1,351 @@
+package impersonation
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/signalable/qauth/internal/audit"
+	"github.com/signalable/qauth/internal/caller"
+	"github.com/signalable/qauth/internal/config"
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/logger"
+	"github.com/signalable/qauth/internal/repository"
+	"github.com/signalable/qauth/internal/usecase"
+	"github.com/signalable/qauth/pkg/jwt"
+)
+
+// indexKey 대리 로그인 세션 ID 목록
+const indexKey = "impersonations"
+
+// HeaderAdminToken 대리 로그인을 시작/폐기하는 관리자 본인의 액세스 토큰 헤더
+const HeaderAdminToken = "X-QAuth-Admin-Token"
+
+// maxReasonLength 대리 로그인 사유 최대 길이
+const maxReasonLength = 500
+
+// Manager 관리자 대리 로그인 세션 발급/조회/폐기
+//
+// 대리 로그인 토큰은 고객을 subject로, 관리자를 act claim으로 담은 위임 토큰이며 jti로 따로 저장해
+// 고객 본인의 세션과 무관하게 폐기할 수 있습니다(고객 전체 로그아웃 시에는 함께 폐기).
+// 관리자는 요청 본문이 아닌 관리자 본인의 토큰으로 확인하고 요청한 내부 호출자와 함께 기록하며,
+// 시작/폐기 이벤트를 감사 로그에 기록하지 못하면 토큰을 발급하거나 폐기하지 않습니다.
+type Manager struct {
+	client      *redis.Client
+	tokenRepo   repository.TokenRepository
+	jwtService  *jwt.Service
+	authUseCase usecase.AuthUseCase
+	audit       *audit.Logger
+	policy      atomic.Pointer[config.ImpersonationConfig]
+}
+
+// NewManager 대리 로그인 관리자 생성자
+func NewManager(
+	client *redis.Client,
+	tokenRepo repository.TokenRepository,
+	jwtService *jwt.Service,
+	authUseCase usecase.AuthUseCase,
+	cfg config.ImpersonationConfig,
+	auditLogger *audit.Logger,
+) *Manager {
+	m := &Manager{
+		client:      client,
+		tokenRepo:   tokenRepo,
+		jwtService:  jwtService,
+		authUseCase: authUseCase,
+		audit:       auditLogger,
+	}
+	m.policy.Store(&cfg)
+	return m
+}
+
+// Prepare 새 정책으로 교체하는 함수 반환 (reload 적용용, 설정은 이미 검증된 상태)
+func (m *Manager) Prepare(cfg config.ImpersonationConfig) (func(), error) {
+	return func() { m.policy.Store(&cfg) }, nil
+}
+
+// Start 대리 로그인 토큰 발급 (요청의 admin_id는 비우거나 관리자 토큰의 사용자와 같아야 함)
+func (m *Manager) Start(ctx context.Context, req domain.ImpersonationRequest) (*domain.IssuedImpersonation, error) {
+	policy := m.policy.Load()
+	if len(policy.Scopes) == 0 {
+		return nil, fmt.Errorf("%w: 대리 로그인이 비활성화되어 있습니다", domain.ErrUnauthorized)
+	}
+	callerID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	adminID, err := m.admin(ctx, req.AdminToken)
+	if err != nil {
+		return nil, err
+	}
+	if req.AdminID != "" && req.AdminID != adminID {
+		return nil, fmt.Errorf("%w: admin_id가 관리자 토큰의 사용자와 다릅니다", domain.ErrUnauthorized)
+	}
+	req.AdminID = adminID
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+	ttl, err := duration(req.Duration, policy)
+	if err != nil {
+		return nil, err
+	}
+	scopes, err := grant(req.Scopes, policy.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("대리 로그인 ID 생성 실패: %w", err)
+	}
+	now := time.Now().UTC()
+	imp := domain.Impersonation{
+		ID:        hex.EncodeToString(buf),
+		UserID:    req.UserID,
+		AdminID:   req.AdminID,
+		CallerID:  callerID,
+		Reason:    req.Reason,
+		Scopes:    scopes,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	scope := strings.Join(scopes, " ")
+	token, err := m.jwtService.GenerateToken(req.UserID,
+		jwt.WithClaim("jti", imp.ID),
+		jwt.WithClaim("act", &domain.Actor{Subject: req.AdminID}),
+		jwt.WithClaim("scope", scope),
+		jwt.WithClaim(domain.ClaimImpersonation, true),
+		jwt.WithLifetime(ttl),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("대리 로그인 토큰 생성 실패: %w", err)
+	}
+
+	// 감사 기록이 남은 뒤에만 토큰을 유효하게 만듦
+	if err := m.audit.Record(ctx, audit.Event{
+		Type:  audit.EventImpersonationStarted,
+		Actor: req.AdminID,
+		Metadata: map[string]string{
+			"caller_id":        callerID,
+			"impersonation_id": imp.ID,
+			"user_id":          req.UserID,
+			"reason":           req.Reason,
+			"scope":            scope,
+			"expires_at":       imp.ExpiresAt.Format(time.RFC3339),
+			"token_fp":         logger.Fingerprint(token),
+		},
+	}); err != nil {
+		return nil, fmt.Errorf("대리 로그인 감사 기록 실패: %w", err)
+	}
+
+	metadata := &domain.TokenMetadata{
+		UserID:    req.UserID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: imp.ExpiresAt.Unix(),
+	}
+	if err := m.tokenRepo.StoreDelegated(ctx, imp.ID, metadata); err != nil {
+		return nil, err
+	}
+	if err := m.save(ctx, &imp); err != nil {
+		return nil, err
+	}
+
+	return &domain.IssuedImpersonation{
+		Impersonation: imp,
+		AccessToken:   token,
+		TokenType:     "Bearer",
+		ExpiresIn:     int64(ttl.Seconds()),
+	}, nil
+}
+
+// List 만료되지 않은 대리 로그인 세션 목록 (userID가 있으면 해당 고객의 세션만)
+func (m *Manager) List(ctx context.Context, userID string) ([]*domain.Impersonation, error) {
+	ids, err := m.client.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("대리 로그인 목록 조회 실패: %w", err)
+	}
+
+	sessions := make([]*domain.Impersonation, 0, len(ids))
+	for _, id := range ids {
+		imp, err := m.Get(ctx, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			// 만료되어 사라진 세션은 목록에서도 제거
+			m.client.SRem(ctx, indexKey, id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if userID != "" && imp.UserID != userID {
+			continue
+		}
+		sessions = append(sessions, imp)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].CreatedAt.Before(sessions[j].CreatedAt) })
+	return sessions, nil
+}
+
+// Get 대리 로그인 세션 조회 (만료되었거나 없으면 ErrNotFound)
+func (m *Manager) Get(ctx context.Context, id string) (*domain.Impersonation, error) {
+	data, err := m.client.Get(ctx, key(id)).Bytes()
+	if err == redis.Nil {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("대리 로그인 조회 실패: %w", err)
+	}
+
+	var imp domain.Impersonation
+	if err := json.Unmarshal(data, &imp); err != nil {
+		return nil, fmt.Errorf("대리 로그인 역직렬화 실패: %w", err)
+	}
+	return &imp, nil
+}
+
+// Revoke 대리 로그인 토큰 폐기 (고객 본인의 세션은 유지, 폐기한 관리자는 adminToken의 사용자)
+//
+// 이미 폐기된 세션은 그대로 반환하며, 폐기 이벤트를 감사 로그에 기록하지 못하면 폐기하지 않습니다.
+func (m *Manager) Revoke(ctx context.Context, id, adminToken string) (*domain.Impersonation, error) {
+	callerID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	revokedBy, err := m.admin(ctx, adminToken)
+	if err != nil {
+		return nil, err
+	}
+	imp, err := m.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if imp.RevokedAt != nil {
+		return imp, nil
+	}
+
+	if err := m.audit.Record(ctx, audit.Event{
+		Type:  audit.EventImpersonationRevoked,
+		Actor: revokedBy,
+		Metadata: map[string]string{
+			"caller_id":        callerID,
+			"impersonation_id": imp.ID,
+			"user_id":          imp.UserID,
+			"admin_id":         imp.AdminID,
+		},
+	}); err != nil {
+		return nil, fmt.Errorf("대리 로그인 폐기 감사 기록 실패: %w", err)
+	}
+
+	// 고객 전체 로그아웃으로 이미 사라진 토큰은 기록만 갱신
+	if err := m.tokenRepo.RevokeDelegated(ctx, id); err != nil && !errors.Is(err, domain.ErrRevokedToken) {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	imp.RevokedAt = &now
+	if err := m.save(ctx, imp); err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+// save 세션 기록 저장 (토큰 만료 시각까지 보관)
+func (m *Manager) save(ctx context.Context, imp *domain.Impersonation) error {
+	data, err := json.Marshal(imp)
+	if err != nil {
+		return fmt.Errorf("대리 로그인 직렬화 실패: %w", err)
+	}
+
+	pipe := m.client.TxPipeline()
+	pipe.Set(ctx, key(imp.ID), data, time.Until(imp.ExpiresAt))
+	pipe.SAdd(ctx, indexKey, imp.ID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("대리 로그인 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// admin 관리자 토큰을 검증해 관리자 ID 반환 (관리자 본인의 토큰이어야 하며 위임/대리 로그인 토큰은 거부)
+func (m *Manager) admin(ctx context.Context, token string) (string, error) {
+	if token == "" {
+		return "", fmt.Errorf("%w: %s 헤더로 관리자 토큰을 보내야 합니다", domain.ErrUnauthorized, HeaderAdminToken)
+	}
+	resp, err := m.authUseCase.ValidateToken(ctx, token)
+	if err != nil {
+		return "", fmt.Errorf("%w: 관리자 토큰 검증 실패: %v", domain.ErrUnauthorized, err)
+	}
+	if resp.Actor != nil || resp.APIKeyID != "" || resp.UserID == "" {
+		return "", fmt.Errorf("%w: 관리자 본인에게 발급된 토큰이어야 합니다", domain.ErrUnauthorized)
+	}
+	return resp.UserID, nil
+}
+
+// callerID 대리 로그인을 요청한 내부 호출자 ID (식별된 호출자만 허용)
+func callerID(ctx context.Context) (string, error) {
+	c := caller.FromContext(ctx)
+	if c == nil || c.ID == "" {
+		return "", fmt.Errorf("%w: 대리 로그인은 식별된 내부 호출자만 사용할 수 있습니다", domain.ErrUnauthorized)
+	}
+	return c.ID, nil
+}
+
+// validate 대리 로그인 요청 필수 값 확인
+func validate(req domain.ImpersonationRequest) error {
+	switch {
+	case req.UserID == "":
+		return fmt.Errorf("%w: user_id가 필요합니다", domain.ErrInvalidRequest)
+	case strings.TrimSpace(req.Reason) == "":
+		return fmt.Errorf("%w: reason이 필요합니다", domain.ErrInvalidRequest)
+	case len(req.Reason) > maxReasonLength:
+		return fmt.Errorf("%w: reason은 %d자 이하여야 합니다", domain.ErrInvalidRequest, maxReasonLength)
+	}
+	return nil
+}
+
+// duration 요청 유효 시간 (비어 있으면 기본값, 최대값 초과는 거부)
+func duration(value string, policy *config.ImpersonationConfig) (time.Duration, error) {
+	if value == "" {
+		return policy.DefaultTTL, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 || d > policy.MaxTTL {
+		return 0, fmt.Errorf("%w: duration은 %s 이하의 양수여야 합니다", domain.ErrInvalidRequest, policy.MaxTTL)
+	}
+	return d, nil
+}
+
+// grant 발급할 scope (요청 scope는 허용 범위 안이어야 하며 비어 있으면 허용 범위 전체)
+func grant(requested, allowed []string) ([]string, error) {
+	if len(requested) == 0 {
+		return allowed, nil
+	}
+
+	scopes := make([]string, 0, len(requested))
+	for _, scope := range requested {
+		found := false
+		for _, a := range allowed {
+			if a == scope {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %q", domain.ErrInvalidScope, scope)
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes, nil
+}
+
+func key(id string) string {
+	return "impersonation:" + id
+}
@@ -0,0 +1,316 @@
+package lockout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/signalable/qauth/internal/audit"
+	"github.com/signalable/qauth/internal/caller"
+	"github.com/signalable/qauth/internal/config"
+	"github.com/signalable/qauth/internal/domain"
+)
+
+// 잠금 대상 종류
+const (
+	KindAccount = "account"
+	KindIP      = "ip"
+)
+
+// lockedKey 잠긴 대상 목록 (멤버 "<kind>:<id>", 점수는 잠금 해제 시각 ms)
+const lockedKey = "lockout:locked"
+
+// failScript 실패 기록 (임계값에 도달하면 잠금)
+//
+// KEYS[1] 대상 해시, KEYS[2] 잠긴 대상 목록
+// ARGV[1] 현재 시각(ms), ARGV[2] 임계값, ARGV[3] 실패 유지 시간(ms), ARGV[4] 잠금 시간(ms), ARGV[5] 목록 멤버
+// 반환값 {실패 횟수, 이번에 잠겼는지 여부}
+var failScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local locked_until = tonumber(redis.call("HGET", KEYS[1], "locked_until"))
+if locked_until and locked_until > now then
+  return {tonumber(redis.call("HGET", KEYS[1], "failures")), 0}
+end
+
+local failures = redis.call("HINCRBY", KEYS[1], "failures", 1)
+redis.call("HSET", KEYS[1], "last_failure", now)
+
+if failures >= tonumber(ARGV[2]) then
+  local until_ms = now + tonumber(ARGV[4])
+  redis.call("HSET", KEYS[1], "locked_until", until_ms)
+  redis.call("PEXPIRE", KEYS[1], ARGV[4])
+  redis.call("ZADD", KEYS[2], until_ms, ARGV[5])
+  return {failures, 1}
+end
+
+redis.call("PEXPIRE", KEYS[1], ARGV[3])
+return {failures, 0}
+`)
+
+// Status 대상의 실패 및 잠금 상태 (관리 API 응답)
+type Status struct {
+	Kind        string     `json:"kind"`
+	ID          string     `json:"id"`
+	Failures    int        `json:"failures"`
+	LastFailure time.Time  `json:"last_failure"`
+	RetryAt     *time.Time `json:"retry_at,omitempty"`
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+}
+
+// Guard 계정/IP별 로그인 실패를 추적해 점진적 지연과 잠금을 적용
+//
+// 로그인 흐름은 자격 증명을 확인하기 전에 Check, 실패 시 Fail, 성공 시 Succeed를 호출합니다.
+// 공격자가 잠금 여부를 알 수 없도록 차단된 시도도 일반 인증 실패와 같은 에러를 반환합니다.
+// 계정은 실패할 때마다 대기 시간이 두 배로 늘고 임계값에서 잠기며,
+// IP는 NAT 뒤의 정상 사용자를 고려해 지연 없이 더 높은 임계값에서만 차단됩니다.
+type Guard struct {
+	client *redis.Client
+	audit  *audit.Logger
+	policy atomic.Pointer[config.LockoutConfig]
+}
+
+// NewGuard Guard 생성자
+func NewGuard(client *redis.Client, cfg config.LockoutConfig, auditLogger *audit.Logger) *Guard {
+	g := &Guard{
+		client: client,
+		audit:  auditLogger,
+	}
+	g.policy.Store(&cfg)
+	return g
+}
+
+// Prepare 새 정책으로 교체하는 함수 반환 (reload 적용용, 설정은 이미 검증된 상태)
+func (g *Guard) Prepare(cfg config.LockoutConfig) (func(), error) {
+	return func() { g.policy.Store(&cfg) }, nil
+}
+
+// Check 로그인 시도 허용 여부 (잠겼거나 대기 시간 중이면 ErrAuthenticationFailed)
+func (g *Guard) Check(ctx context.Context, account, ip string) error {
+	now := time.Now()
+	for _, target := range targets(account, ip) {
+		status, err := g.status(ctx, target.kind, target.id)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if status.LockedUntil != nil && now.Before(*status.LockedUntil) {
+			slog.InfoContext(ctx, "잠긴 대상의 로그인 시도 거부", slog.String("kind", target.kind), slog.String("id", target.id))
+			return domain.ErrAuthenticationFailed
+		}
+		if status.RetryAt != nil && now.Before(*status.RetryAt) {
+			slog.InfoContext(ctx, "대기 시간 중 로그인 시도 거부", slog.String("kind", target.kind), slog.String("id", target.id))
+			return domain.ErrAuthenticationFailed
+		}
+	}
+	return nil
+}
+
+// Fail 로그인 실패 기록 (login.failed 감사 이벤트를 남기고, 임계값에 도달한 대상은 잠금)
+func (g *Guard) Fail(ctx context.Context, account, ip string) error {
+	g.audit.Emit(ctx, audit.Event{
+		Type:   audit.EventLoginFailed,
+		Actor:  account,
+		IP:     ip,
+		Reason: "invalid_credentials",
+	})
+
+	policy := g.policy.Load()
+	now := time.Now().UnixMilli()
+
+	for _, target := range targets(account, ip) {
+		threshold := policy.MaxFailures
+		if target.kind == KindIP {
+			threshold = policy.IPMaxFailures
+		}
+
+		values, err := failScript.Run(ctx, g.client, []string{key(target.kind, target.id), lockedKey},
+			now,
+			threshold,
+			policy.FailureWindow.Milliseconds(),
+			policy.LockDuration.Milliseconds(),
+			member(target.kind, target.id),
+		).Int64Slice()
+		if err != nil {
+			return fmt.Errorf("로그인 실패 기록 실패: %w", err)
+		}
+
+		if values[1] == 1 {
+			slog.WarnContext(ctx, "로그인 실패 누적으로 잠금", slog.String("kind", target.kind), slog.String("id", target.id), slog.Int64("failures", values[0]))
+			g.audit.Emit(ctx, audit.Event{
+				Type:   audit.EventLockoutLocked,
+				Actor:  target.id,
+				IP:     ip,
+				Reason: "too_many_failures",
+				Metadata: map[string]string{
+					"kind":         target.kind,
+					"failures":     strconv.FormatInt(values[0], 10),
+					"locked_until": time.UnixMilli(now).Add(policy.LockDuration).UTC().Format(time.RFC3339),
+				},
+			})
+		}
+	}
+	return nil
+}
+
+// Succeed 로그인 성공 시 계정의 실패 기록 초기화 (IP 기록은 다른 계정 대상 실패도 포함하므로 유지)
+func (g *Guard) Succeed(ctx context.Context, account, ip string) error {
+	if err := g.client.Del(ctx, key(KindAccount, account)).Err(); err != nil {
+		return fmt.Errorf("로그인 실패 기록 초기화 실패: %w", err)
+	}
+
+	g.audit.Emit(ctx, audit.Event{
+		Type:  audit.EventLoginSucceeded,
+		Actor: account,
+		IP:    ip,
+	})
+	return nil
+}
+
+// Status 대상의 실패 및 잠금 상태 (기록이 없으면 ErrNotFound)
+func (g *Guard) Status(ctx context.Context, kind, id string) (*Status, error) {
+	if err := validKind(kind); err != nil {
+		return nil, err
+	}
+	return g.status(ctx, kind, id)
+}
+
+// Locked 현재 잠긴 대상 목록
+func (g *Guard) Locked(ctx context.Context) ([]*Status, error) {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	if err := g.client.ZRemRangeByScore(ctx, lockedKey, "-inf", now).Err(); err != nil {
+		return nil, fmt.Errorf("잠금 목록 조회 실패: %w", err)
+	}
+	members, err := g.client.ZRange(ctx, lockedKey, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("잠금 목록 조회 실패: %w", err)
+	}
+
+	statuses := make([]*Status, 0, len(members))
+	for _, m := range members {
+		kind, id, _ := strings.Cut(m, ":")
+		status, err := g.status(ctx, kind, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Clear 대상의 실패 기록과 잠금 해제 (관리자 작업으로 감사 이벤트 기록, Actor는 해제한 내부 호출자)
+func (g *Guard) Clear(ctx context.Context, kind, id string) error {
+	if err := validKind(kind); err != nil {
+		return err
+	}
+
+	pipe := g.client.TxPipeline()
+	deleted := pipe.Del(ctx, key(kind, id))
+	pipe.ZRem(ctx, lockedKey, member(kind, id))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("잠금 해제 실패: %w", err)
+	}
+	if deleted.Val() == 0 {
+		return domain.ErrNotFound
+	}
+
+	g.audit.Emit(ctx, audit.Event{
+		Type:     audit.EventLockoutCleared,
+		Actor:    actor(ctx),
+		Metadata: map[string]string{"kind": kind, "id": id},
+	})
+	return nil
+}
+
+// actor 잠금을 해제한 내부 호출자 ID (감사 이벤트의 Actor, 해제 대상은 metadata의 kind와 id)
+func actor(ctx context.Context) string {
+	if c := caller.FromContext(ctx); c != nil {
+		return c.ID
+	}
+	return ""
+}
+
+func (g *Guard) status(ctx context.Context, kind, id string) (*Status, error) {
+	fields, err := g.client.HGetAll(ctx, key(kind, id)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("잠금 상태 조회 실패: %w", err)
+	}
+	if len(fields) == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	failures, _ := strconv.Atoi(fields["failures"])
+	lastFailure, _ := strconv.ParseInt(fields["last_failure"], 10, 64)
+	status := &Status{
+		Kind:        kind,
+		ID:          id,
+		Failures:    failures,
+		LastFailure: time.UnixMilli(lastFailure).UTC(),
+	}
+
+	if lockedUntil, err := strconv.ParseInt(fields["locked_until"], 10, 64); err == nil {
+		t := time.UnixMilli(lockedUntil).UTC()
+		status.LockedUntil = &t
+	}
+	if kind == KindAccount && failures > 0 {
+		t := status.LastFailure.Add(g.delay(failures))
+		status.RetryAt = &t
+	}
+	return status, nil
+}
+
+// delay 실패 횟수에 따른 다음 시도까지 대기 시간 (BaseDelay * 2^(failures-1), 최대 MaxDelay)
+func (g *Guard) delay(failures int) time.Duration {
+	policy := g.policy.Load()
+	d := policy.BaseDelay
+	for i := 1; i < failures && d < policy.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > policy.MaxDelay {
+		return policy.MaxDelay
+	}
+	return d
+}
+
+type target struct {
+	kind, id string
+}
+
+// targets 기록할 대상 목록 (빈 값은 제외)
+func targets(account, ip string) []target {
+	var ts []target
+	if account != "" {
+		ts = append(ts, target{KindAccount, account})
+	}
+	if ip != "" {
+		ts = append(ts, target{KindIP, ip})
+	}
+	return ts
+}
+
+func validKind(kind string) error {
+	if kind != KindAccount && kind != KindIP {
+		return domain.ErrInvalidRequest
+	}
+	return nil
+}
+
+func key(kind, id string) string {
+	return "lockout:" + kind + ":" + id
+}
+
+func member(kind, id string) string {
+	return kind + ":" + id
+}
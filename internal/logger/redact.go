@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// sensitiveKeys 값을 항상 지문으로 바꿔 기록할 속성 키
+var sensitiveKeys = map[string]bool{
+	"token":          true,
+	"access_token":   true,
+	"refresh_token":  true,
+	"authorization":  true,
+	"password":       true,
+	"secret":         true,
+	"secret_key":     true,
+	"jwt_secret_key": true,
+	"api_key":        true,
+	"client_secret":  true,
+	"hmac_secret":    true,
+	"caller_key":     true,
+}
+
+// jwtPattern 키와 무관하게 문자열 안에 섞인 JWT 탐지
+var jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+
+// apiKeyPattern 문자열 안에 섞인 API 키 ("qak_<ID>_<비밀 값>", ID는 남기고 비밀 값만 치환)
+var apiKeyPattern = regexp.MustCompile(`(qak_[0-9a-f]+_)([A-Za-z0-9_-]+)`)
+
+// credentialPattern 에러 메시지 등에 "이름: 값", "이름=값" 형태로 섞인 호출자 자격 증명 탐지
+var credentialPattern = regexp.MustCompile(`(?i)((?:x-qauth-caller-key|x-api-key|hmac_secret|caller_key|client_secret|api_key)["']?\s*[:=]\s*["']?)([^\s"',;&]+)`)
+
+// Fingerprint 비밀 값의 식별용 지문 (원문 복원 불가)
+func Fingerprint(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(secret))
+	return "sha256:" + hex.EncodeToString(sum[:6])
+}
+
+// Token 토큰 지문 속성
+func Token(token string) slog.Attr {
+	return slog.String("token_fp", Fingerprint(token))
+}
+
+// redactAttr 민감한 속성 값을 지문으로 치환
+func redactAttr(_ []string, attr slog.Attr) slog.Attr {
+	if sensitiveKeys[strings.ToLower(attr.Key)] {
+		return slog.String(attr.Key, Fingerprint(attr.Value.String()))
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		if s := attr.Value.String(); containsSecret(s) {
+			return slog.String(attr.Key, redactString(s))
+		}
+	case slog.KindAny:
+		// 에러 메시지 등에 토큰이나 API 키가 섞여 들어온 경우
+		if err, ok := attr.Value.Any().(error); ok {
+			if s := err.Error(); containsSecret(s) {
+				return slog.String(attr.Key, redactString(s))
+			}
+		}
+	}
+	return attr
+}
+
+// containsSecret 문자열 안에 JWT, API 키, 호출자 자격 증명이 섞여 있는지 여부
+func containsSecret(s string) bool {
+	return jwtPattern.MatchString(s) || apiKeyPattern.MatchString(s) || credentialPattern.MatchString(s)
+}
+
+// redactString 문자열 안의 JWT, API 키 비밀 값, 호출자 자격 증명을 지문으로 치환
+func redactString(s string) string {
+	s = credentialPattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := credentialPattern.FindStringSubmatch(match)
+		return parts[1] + Fingerprint(parts[2])
+	})
+	s = apiKeyPattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := apiKeyPattern.FindStringSubmatch(match)
+		return parts[1] + Fingerprint(parts[2])
+	})
+	return jwtPattern.ReplaceAllStringFunc(s, Fingerprint)
+}
@@ -0,0 +1,20 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor 메서드와 상태 코드별 gRPC 지연 시간 기록 인터셉터
+func (m *Metrics) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	m.grpcRequestDuration.
+		WithLabelValues(info.FullMethod, status.Code(err).String()).
+		Observe(time.Since(start).Seconds())
+	return resp, err
+}
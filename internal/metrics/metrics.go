@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "qauth"
+
+// Metrics qauth Prometheus 지표 모음
+type Metrics struct {
+	registry *prometheus.Registry
+
+	tokenOperations        *prometheus.CounterVec
+	tokenOperationDuration *prometheus.HistogramVec
+	httpRequestDuration    *prometheus.HistogramVec
+	grpcRequestDuration    *prometheus.HistogramVec
+	redisCommandDuration   *prometheus.HistogramVec
+	redisErrors            *prometheus.CounterVec
+	activeSessions         prometheus.Gauge
+	rateLimitRejections    *prometheus.CounterVec
+}
+
+// New 지표 생성 및 전용 레지스트리 등록
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		tokenOperations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "token_operations_total",
+			Help:      "Token operations by operation (issue, validate, refresh, revoke, revoke_all), outcome and domain error.",
+		}, []string{"operation", "outcome", "error"}),
+		tokenOperationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "token_operation_duration_seconds",
+			Help:      "Token operation latency.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route template, method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		grpcRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "grpc_request_duration_seconds",
+			Help:      "gRPC unary call latency by full method name and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		redisCommandDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "redis_command_duration_seconds",
+			Help:      "Redis command latency.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"command"}),
+		redisErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "redis_errors_total",
+			Help:      "Redis command errors (cache misses excluded).",
+		}, []string{"command"}),
+		activeSessions: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "active_sessions",
+			Help:      "Number of active token sessions stored in Redis.",
+		}),
+		rateLimitRejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rate_limit_rejections_total",
+			Help:      "Requests rejected by rate limiting, by configured route path and key type.",
+		}, []string{"route", "key"}),
+	}
+
+	m.registry.MustRegister(
+		m.tokenOperations,
+		m.tokenOperationDuration,
+		m.httpRequestDuration,
+		m.grpcRequestDuration,
+		m.redisCommandDuration,
+		m.redisErrors,
+		m.activeSessions,
+		m.rateLimitRejections,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// Registry 지표 레지스트리 (다른 모듈의 지표 등록용)
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Handler /metrics 핸들러
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
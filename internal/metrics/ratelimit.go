@@ -0,0 +1,6 @@
+package metrics
+
+// RateLimitRejected 요청 제한으로 거부된 요청 기록 (route는 설정의 경로 패턴, key는 키 종류)
+func (m *Metrics) RateLimitRejected(route, key string) {
+	m.rateLimitRejections.WithLabelValues(route, key).Inc()
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type redisStartKey struct{}
+
+// redisHook Redis 명령 지연 시간과 에러를 기록하는 go-redis 훅
+type redisHook struct {
+	metrics *Metrics
+}
+
+// RedisHook Redis 클라이언트에 등록할 지표 훅
+func (m *Metrics) RedisHook() redis.Hook {
+	return &redisHook{metrics: m}
+}
+
+func (h *redisHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	return context.WithValue(ctx, redisStartKey{}, time.Now()), nil
+}
+
+func (h *redisHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	h.observe(ctx, cmd.Name(), []redis.Cmder{cmd})
+	return nil
+}
+
+func (h *redisHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	return context.WithValue(ctx, redisStartKey{}, time.Now()), nil
+}
+
+func (h *redisHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	h.observe(ctx, "pipeline", cmds)
+	return nil
+}
+
+func (h *redisHook) observe(ctx context.Context, name string, cmds []redis.Cmder) {
+	if start, ok := ctx.Value(redisStartKey{}).(time.Time); ok {
+		h.metrics.redisCommandDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+	}
+
+	for _, cmd := range cmds {
+		if err := cmd.Err(); err != nil && err != redis.Nil {
+			h.metrics.redisErrors.WithLabelValues(cmd.Name()).Inc()
+		}
+	}
+}
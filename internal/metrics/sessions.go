@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// SessionCounter 활성 세션 수 조회 함수
+type SessionCounter func(ctx context.Context) (int64, error)
+
+// RunSessionGauge ctx가 취소될 때까지 주기적으로 활성 세션 수 갱신
+func (m *Metrics) RunSessionGauge(ctx context.Context, count SessionCounter, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		m.updateSessions(ctx, count, interval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Metrics) updateSessions(ctx context.Context, count SessionCounter, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	n, err := count(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "활성 세션 수 조회 실패", slog.Any("error", err))
+		return
+	}
+	m.activeSessions.Set(float64(n))
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/usecase"
+)
+
+// 토큰 작업 이름
+const (
+	operationIssue     = "issue"
+	operationValidate  = "validate"
+	operationRefresh   = "refresh"
+	operationRevoke    = "revoke"
+	operationRevokeAll = "revoke_all"
+	operationMetadata  = "metadata"
+)
+
+type instrumentedAuthUseCase struct {
+	next    usecase.AuthUseCase
+	metrics *Metrics
+}
+
+// InstrumentAuthUseCase 토큰 작업 지표를 기록하는 AuthUseCase 래퍼
+func InstrumentAuthUseCase(next usecase.AuthUseCase, m *Metrics) usecase.AuthUseCase {
+	return &instrumentedAuthUseCase{
+		next:    next,
+		metrics: m,
+	}
+}
+
+func (uc *instrumentedAuthUseCase) CreateToken(ctx context.Context, userID string) (*domain.AuthResponse, error) {
+	defer uc.metrics.observe(operationIssue, time.Now())
+	resp, err := uc.next.CreateToken(ctx, userID)
+	uc.metrics.record(operationIssue, err)
+	return resp, err
+}
+
+func (uc *instrumentedAuthUseCase) ValidateToken(ctx context.Context, token string) (*domain.TokenValidationResponse, error) {
+	defer uc.metrics.observe(operationValidate, time.Now())
+	resp, err := uc.next.ValidateToken(ctx, token)
+	uc.metrics.record(operationValidate, err)
+	return resp, err
+}
+
+func (uc *instrumentedAuthUseCase) RevokeToken(ctx context.Context, token string) error {
+	defer uc.metrics.observe(operationRevoke, time.Now())
+	err := uc.next.RevokeToken(ctx, token)
+	uc.metrics.record(operationRevoke, err)
+	return err
+}
+
+func (uc *instrumentedAuthUseCase) RevokeAllTokens(ctx context.Context, userID string) error {
+	defer uc.metrics.observe(operationRevokeAll, time.Now())
+	err := uc.next.RevokeAllTokens(ctx, userID)
+	uc.metrics.record(operationRevokeAll, err)
+	return err
+}
+
+func (uc *instrumentedAuthUseCase) RefreshToken(ctx context.Context, oldToken string) (*domain.AuthResponse, error) {
+	defer uc.metrics.observe(operationRefresh, time.Now())
+	resp, err := uc.next.RefreshToken(ctx, oldToken)
+	uc.metrics.record(operationRefresh, err)
+	return resp, err
+}
+
+func (uc *instrumentedAuthUseCase) GetTokenMetadata(ctx context.Context, token string) (*domain.TokenMetadata, error) {
+	defer uc.metrics.observe(operationMetadata, time.Now())
+	metadata, err := uc.next.GetTokenMetadata(ctx, token)
+	uc.metrics.record(operationMetadata, err)
+	return metadata, err
+}
+
+// record 작업 결과 카운트
+func (m *Metrics) record(operation string, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+	}
+	m.tokenOperations.WithLabelValues(operation, outcome, domain.ErrorCode(err)).Inc()
+}
+
+// observe 작업 소요 시간 기록
+func (m *Metrics) observe(operation string, start time.Time) {
+	m.tokenOperationDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,41 @@
+package proxy
+
+import (
+	"net"
+	"strings"
+
+	"github.com/signalable/qauth/internal/config"
+)
+
+// Audiences 원 요청 호스트/경로별로 허용하는 토큰 aud 규칙
+type Audiences []config.AudienceRoute
+
+// Match 원 요청에 먼저 일치한 규칙의 aud 목록 (일치하는 규칙이 없으면 false)
+//
+// host는 포트를 포함할 수 있고, uri는 query를 포함할 수 있습니다.
+func (a Audiences) Match(host, uri string) ([]string, bool) {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	path, _, _ := strings.Cut(uri, "?")
+
+	for _, route := range a {
+		if route.Host != "" && !strings.EqualFold(route.Host, host) {
+			continue
+		}
+		if !underPrefix(path, route.PathPrefix) {
+			continue
+		}
+		return route.Audiences, true
+	}
+	return nil, false
+}
+
+// underPrefix 경로가 접두사와 같거나 그 하위 경로인지 여부 ("/orders"는 "/orders-admin"과 일치하지 않음)
+func underPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return true
+	}
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
@@ -0,0 +1,41 @@
+package proxy
+
+import (
+	"net"
+
+	"github.com/signalable/qauth/internal/config"
+)
+
+// Trusted X-Forwarded-* 등 원 요청 정보를 전달할 수 있는 프록시 주소 목록
+type Trusted []*net.IPNet
+
+// ParseTrusted IP 또는 CIDR 목록 파싱
+func ParseTrusted(values []string) (Trusted, error) {
+	trusted := make(Trusted, 0, len(values))
+	for _, value := range values {
+		network, err := config.ParseNetwork(value)
+		if err != nil {
+			return nil, err
+		}
+		trusted = append(trusted, network)
+	}
+	return trusted, nil
+}
+
+// Contains 요청 연결 주소(host:port 또는 IP)가 신뢰하는 프록시인지 여부
+func (t Trusted) Contains(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range t {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// gcraScript GCRA(Generic Cell Rate Algorithm) 판정
+//
+// 키에는 다음 요청이 도착할 것으로 예상되는 이론상 시각(TAT)만 저장하므로 요청 수와 무관하게
+// 키당 값 하나로 동작합니다. 인스턴스 간 시계 차이를 피하기 위해 Redis 서버 시각을 사용합니다.
+//
+// KEYS[1] 키, ARGV[1] burst, ARGV[2] 발행 간격(초)
+// 반환값 {허용 여부, 남은 요청 수, 재시도까지 남은 시간(초), 한도 회복까지 남은 시간(초)}
+var gcraScript = redis.NewScript(`
+redis.replicate_commands()
+
+local key = KEYS[1]
+local burst = tonumber(ARGV[1])
+local emission_interval = tonumber(ARGV[2])
+local burst_offset = emission_interval * burst
+
+local time = redis.call("TIME")
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+
+local tat = tonumber(redis.call("GET", key))
+if not tat or tat < now then
+  tat = now
+end
+
+local new_tat = tat + emission_interval
+local allow_at = new_tat - burst_offset
+local diff = now - allow_at
+local remaining = math.floor(diff / emission_interval + 0.000001)
+
+if remaining < 0 then
+  return {0, 0, tostring(-diff), tostring(tat - now)}
+end
+
+local reset_after = new_tat - now
+redis.call("SET", key, string.format("%.6f", new_tat), "PX", math.ceil(reset_after * 1000))
+return {1, remaining, "0", tostring(reset_after)}
+`)
+
+// Limit 기간당 허용 요청 수
+type Limit struct {
+	// Rate Period 동안 허용하는 요청 수
+	Rate   int
+	Period time.Duration
+
+	// Burst 연속으로 허용하는 최대 요청 수 (0이면 Rate)
+	Burst int
+}
+
+func (l Limit) burst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Rate
+}
+
+// Policy RateLimit-Policy 헤더 값 (예: "30;w=60")
+func (l Limit) Policy() string {
+	return fmt.Sprintf("%d;w=%d", l.burst(), int(l.Period.Seconds()))
+}
+
+// Result 판정 결과
+type Result struct {
+	Allowed   bool
+	Limit     Limit
+	Remaining int
+
+	// RetryAfter 거부된 경우 다음 요청이 허용될 때까지 남은 시간
+	RetryAfter time.Duration
+
+	// ResetAfter 한도가 완전히 회복될 때까지 남은 시간
+	ResetAfter time.Duration
+}
+
+// Limiter Redis 기반 분산 요청 제한기 (여러 인스턴스가 같은 한도를 공유)
+type Limiter struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewLimiter Limiter 생성자 (prefix는 Redis 키 접두사)
+func NewLimiter(client *redis.Client, prefix string) *Limiter {
+	return &Limiter{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Allow key로 요청 하나를 판정
+func (l *Limiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	emissionInterval := limit.Period.Seconds() / float64(limit.Rate)
+
+	values, err := gcraScript.Run(ctx, l.client, []string{l.prefix + key},
+		limit.burst(),
+		strconv.FormatFloat(emissionInterval, 'f', -1, 64),
+	).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("요청 제한 판정 실패: %w", err)
+	}
+	if len(values) != 4 {
+		return Result{}, fmt.Errorf("요청 제한 판정 실패: 예상하지 못한 응답 %v", values)
+	}
+
+	allowed, _ := values[0].(int64)
+	remaining, _ := values[1].(int64)
+	return Result{
+		Allowed:    allowed == 1,
+		Limit:      limit,
+		Remaining:  int(remaining),
+		RetryAfter: seconds(values[2]),
+		ResetAfter: seconds(values[3]),
+	}, nil
+}
+
+// seconds Lua 스크립트가 문자열로 반환한 초 단위 값 변환 (Redis는 Lua 실수를 정수로 잘라 반환)
+func seconds(v interface{}) time.Duration {
+	s, _ := v.(string)
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(f * float64(time.Second))
+}
@@ -0,0 +1,224 @@
+package ratelimit
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/signalable/qauth/internal/audit"
+	"github.com/signalable/qauth/internal/caller"
+	"github.com/signalable/qauth/internal/config"
+	"github.com/signalable/qauth/internal/delivery/http/response"
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/dpop"
+	"github.com/signalable/qauth/internal/logger"
+)
+
+// 응답 헤더 (draft-ietf-httpapi-ratelimit-headers)
+const (
+	HeaderLimit     = "RateLimit-Limit"
+	HeaderRemaining = "RateLimit-Remaining"
+	HeaderReset     = "RateLimit-Reset"
+	HeaderPolicy    = "RateLimit-Policy"
+)
+
+// RejectFunc 거부된 요청 통지 함수 (route는 설정의 경로 패턴, key는 키 종류)
+type RejectFunc func(route, key string)
+
+// rules 한 시점의 전체 한도 설정 (reload 시 통째로 교체)
+type rules struct {
+	enabled bool
+	routes  []config.RateLimitRoute
+}
+
+// Middleware 경로별 한도를 적용하는 HTTP 미들웨어
+//
+// ip, token 키 한도는 Handler를 라우터 미들웨어로 등록해 인증 전에 적용하고 (AuditContext 뒤),
+// user, client 키 한도는 Identified를 인증 미들웨어 뒤에 연결해 인증된 사용자/호출자 기준으로 적용합니다.
+// 인증 미들웨어를 거치지 않는 경로(anonymous)의 user, client 키 한도는 Handler에서 IP 기준으로 적용합니다.
+// 클라이언트가 보낸 헤더는 한도 키로 쓰지 않으며, 인증된 신원이 없으면 IP 기준으로 적용합니다.
+// Redis 장애 시에는 인증 서비스 전체가 멈추지 않도록 제한 없이 통과시킵니다.
+type Middleware struct {
+	limiter   *Limiter
+	rules     atomic.Pointer[rules]
+	anonymous []string
+	onReject  RejectFunc
+}
+
+// NewMiddleware 요청 제한 미들웨어 생성자
+//
+// anonymous는 인증 미들웨어를 거치지 않는 경로 (정확한 경로 또는 "*"로 끝나는 접두사), onReject는 nil 가능
+func NewMiddleware(limiter *Limiter, cfg config.RateLimitConfig, anonymous []string, onReject RejectFunc) *Middleware {
+	m := &Middleware{
+		limiter:   limiter,
+		anonymous: anonymous,
+		onReject:  onReject,
+	}
+	m.rules.Store(compile(cfg))
+	return m
+}
+
+// Prepare 새 한도로 교체하는 함수 반환 (reload 적용용, 설정은 이미 검증된 상태)
+func (m *Middleware) Prepare(cfg config.RateLimitConfig) (func(), error) {
+	r := compile(cfg)
+	return func() { m.rules.Store(r) }, nil
+}
+
+// Handler 인증 전에 판단할 수 있는 ip, token 키 한도와 anonymous 경로의 모든 한도를 적용하는 http.Handler
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if m.allow(w, r, false) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// Identified 인증된 사용자/호출자 기준의 user, client 키 한도를 적용하는 미들웨어 (인증 미들웨어 뒤에 연결)
+func (m *Middleware) Identified(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if m.allow(w, r, true) {
+			next(w, r)
+		}
+	}
+}
+
+// allow 경로에 일치하는 한도 적용 (identified면 user, client 키, 아니면 ip, token 키 한도)
+//
+// 인증 미들웨어를 거치지 않는 경로면 Identified가 실행되지 않으므로 user, client 키 한도도 여기서 적용합니다.
+// 한도를 넘으면 429 응답을 쓰고 false를 반환합니다.
+func (m *Middleware) allow(w http.ResponseWriter, r *http.Request, identified bool) bool {
+	rules := m.rules.Load()
+	if !rules.enabled {
+		return true
+	}
+
+	anonymous := !identified && m.isAnonymous(r.URL.Path)
+	var (
+		tightest *Result
+		rejected *Result
+	)
+	for _, route := range rules.routes {
+		afterAuth := identityKey(route.Key) && !anonymous
+		if afterAuth != identified || !matchPath(route.Path, r.URL.Path) {
+			continue
+		}
+
+		keyType, value := key(r, route.Key)
+		result, err := m.limiter.Allow(r.Context(), route.Path+":"+keyType+":"+value, Limit{
+			Rate:   route.Limit,
+			Period: route.Period,
+			Burst:  route.Burst,
+		})
+		if err != nil {
+			slog.WarnContext(r.Context(), "요청 제한 판정 실패, 제한 없이 통과", slog.String("route", route.Path), slog.Any("error", err))
+			continue
+		}
+
+		if !result.Allowed {
+			if m.onReject != nil {
+				m.onReject(route.Path, keyType)
+			}
+			if rejected == nil || result.RetryAfter > rejected.RetryAfter {
+				rejected = &result
+			}
+		}
+		if tightest == nil || result.Remaining < tightest.Remaining {
+			tightest = &result
+		}
+	}
+
+	if rejected != nil {
+		setHeaders(w, rejected)
+		w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(rejected.RetryAfter)))
+		slog.InfoContext(r.Context(), "요청 제한 초과", slog.String("path", r.URL.Path))
+		response.Error(w, r, domain.ErrRateLimited)
+		return false
+	}
+	if tightest != nil && tighterThanSent(w, tightest) {
+		setHeaders(w, tightest)
+	}
+	return true
+}
+
+// isAnonymous 인증 미들웨어를 거치지 않는 경로인지 여부
+func (m *Middleware) isAnonymous(requestPath string) bool {
+	for _, pattern := range m.anonymous {
+		if matchPath(pattern, requestPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func compile(cfg config.RateLimitConfig) *rules {
+	return &rules{
+		enabled: cfg.Enabled,
+		routes:  append([]config.RateLimitRoute(nil), cfg.Routes...),
+	}
+}
+
+// identityKey 인증 후에 판단해야 하는 키 종류인지 여부
+func identityKey(keyType string) bool {
+	return keyType == config.RateLimitKeyUser || keyType == config.RateLimitKeyClient
+}
+
+// key 한도를 나눌 키 종류와 값 (값이 없으면 IP로 대체)
+func key(r *http.Request, keyType string) (string, string) {
+	info := audit.RequestInfoFromContext(r.Context())
+
+	var value string
+	switch keyType {
+	case config.RateLimitKeyUser:
+		value = info.User
+	case config.RateLimitKeyClient:
+		// X-Client-ID 헤더가 아닌 인증된 호출자 ID (호출자 목록이 비어 있으면 인증서 식별자)
+		if c := caller.FromContext(r.Context()); c != nil {
+			value = c.ID
+		}
+	case config.RateLimitKeyToken:
+		// 토큰 원문이 Redis 키에 남지 않도록 지문 사용 (Bearer, DPoP 스킴 모두)
+		scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+		token = strings.TrimSpace(token)
+		if ok && token != "" && (strings.EqualFold(scheme, "Bearer") || strings.EqualFold(scheme, dpop.Scheme)) {
+			value = logger.Fingerprint(token)
+		}
+	}
+	if value == "" {
+		return config.RateLimitKeyIP, info.IP
+	}
+	return keyType, value
+}
+
+// tighterThanSent 앞 단계에서 쓴 RateLimit-Remaining보다 남은 요청 수가 적은지 여부
+func tighterThanSent(w http.ResponseWriter, result *Result) bool {
+	sent, err := strconv.Atoi(w.Header().Get(HeaderRemaining))
+	return err != nil || result.Remaining < sent
+}
+
+// setHeaders RateLimit-* 응답 헤더 작성
+func setHeaders(w http.ResponseWriter, result *Result) {
+	w.Header().Set(HeaderLimit, strconv.Itoa(result.Limit.burst()))
+	w.Header().Set(HeaderRemaining, strconv.Itoa(result.Remaining))
+	w.Header().Set(HeaderReset, strconv.Itoa(ceilSeconds(result.ResetAfter)))
+	w.Header().Set(HeaderPolicy, result.Limit.Policy())
+}
+
+// ceilSeconds 초 단위 올림 (0이면 즉시 재시도가 가능한 것으로 오해하지 않도록 최소 1초)
+func ceilSeconds(d time.Duration) int {
+	s := int((d + time.Second - 1) / time.Second)
+	if s < 1 {
+		return 1
+	}
+	return s
+}
+
+// matchPath 정확한 경로 또는 "*"로 끝나는 접두사 일치 여부
+func matchPath(pattern, requestPath string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(requestPath, prefix)
+	}
+	return pattern == requestPath
+}
@@ -0,0 +1,195 @@
+package reload
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/signalable/qauth/internal/audit"
+	"github.com/signalable/qauth/internal/config"
+)
+
+// Func 새 설정을 적용할 준비를 하고 실제 반영 함수를 반환
+//
+// 준비 단계(키 파일 읽기 등)에서 하나라도 실패하면 어떤 변경도 반영하지 않습니다.
+type Func func(cfg *config.Config) (commit func(), err error)
+
+// Changes 한 번의 reload에서 바뀐 설정 항목
+type Changes []string
+
+// Any 주어진 항목 중 하나라도 바뀌었는지 여부
+func (c Changes) Any(keys ...string) bool {
+	for _, changed := range c {
+		for _, key := range keys {
+			if changed == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type applier struct {
+	name string
+	keys []string
+	fn   Func
+}
+
+// Reloader 설정과 키 파일을 다시 읽어 실행 중인 구성요소에 반영
+type Reloader struct {
+	load     func() (*config.Config, error)
+	audit    *audit.Logger
+	appliers []applier
+
+	mu           sync.Mutex
+	current      atomic.Pointer[config.Config]
+	fingerprints map[string]string
+}
+
+// New Reloader 생성자 (load는 설정 소스 전체를 다시 읽어 검증하는 함수)
+func New(initial *config.Config, load func() (*config.Config, error), auditLogger *audit.Logger) *Reloader {
+	r := &Reloader{
+		load:  load,
+		audit: auditLogger,
+	}
+	r.current.Store(initial)
+	r.fingerprints = fingerprints(initial)
+	return r
+}
+
+// Register 설정 항목 변경 시 호출할 적용 함수 등록 (등록되지 않은 항목은 재시작이 필요)
+func (r *Reloader) Register(name string, keys []string, fn Func) {
+	r.appliers = append(r.appliers, applier{name: name, keys: keys, fn: fn})
+}
+
+// Current 현재 적용된 설정
+func (r *Reloader) Current() *config.Config {
+	return r.current.Load()
+}
+
+// Reload 설정을 다시 읽고 바뀐 항목을 반영
+//
+// 새 설정이 검증에 실패하거나 적용 준비에 실패하면 기존 설정을 유지합니다.
+func (r *Reloader) Reload(ctx context.Context, trigger string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old := r.current.Load()
+	next, err := r.load()
+	if err != nil {
+		r.fail(ctx, trigger, err)
+		return err
+	}
+
+	nextFingerprints := fingerprints(next)
+	changes := Changes(config.Diff(old, next))
+	for key, fp := range nextFingerprints {
+		// 경로는 같지만 파일 내용이 바뀐 경우
+		if fp != r.fingerprints[key] && !changes.Any(key) {
+			changes = append(changes, key)
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	sort.Strings(changes)
+
+	// 적용 준비 (실패하면 아무것도 반영하지 않음)
+	var commits []func()
+	var applied []string
+	for _, a := range r.appliers {
+		if !changes.Any(a.keys...) {
+			continue
+		}
+		commit, err := a.fn(next)
+		if err != nil {
+			err = fmt.Errorf("%s 적용 준비 실패: %w", a.name, err)
+			r.fail(ctx, trigger, err)
+			return err
+		}
+		commits = append(commits, commit)
+		applied = append(applied, a.name)
+	}
+
+	for _, commit := range commits {
+		commit()
+	}
+	r.current.Store(next)
+	r.fingerprints = nextFingerprints
+
+	restartRequired := r.restartRequired(changes)
+	slog.InfoContext(ctx, "설정 다시 읽기 완료",
+		slog.String("trigger", trigger),
+		slog.Any("changed", []string(changes)),
+		slog.Any("applied", applied),
+		slog.Any("restart_required", restartRequired),
+	)
+	r.audit.Emit(ctx, audit.Event{
+		Type: audit.EventConfigReloaded,
+		Metadata: map[string]string{
+			"trigger":          trigger,
+			"changed":          strings.Join(changes, ","),
+			"applied":          strings.Join(applied, ","),
+			"restart_required": strings.Join(restartRequired, ","),
+		},
+	})
+	return nil
+}
+
+// restartRequired 적용 함수가 없어 재시작해야 반영되는 항목
+func (r *Reloader) restartRequired(changes Changes) []string {
+	var pending []string
+	for _, changed := range changes {
+		handled := false
+		for _, a := range r.appliers {
+			if Changes(a.keys).Any(changed) {
+				handled = true
+				break
+			}
+		}
+		if !handled {
+			pending = append(pending, changed)
+		}
+	}
+	return pending
+}
+
+func (r *Reloader) fail(ctx context.Context, trigger string, err error) {
+	slog.ErrorContext(ctx, "설정 다시 읽기 실패, 기존 설정 유지", slog.String("trigger", trigger), slog.Any("error", err))
+	r.audit.Emit(ctx, audit.Event{
+		Type:     audit.EventConfigReloadFailed,
+		Reason:   err.Error(),
+		Metadata: map[string]string{"trigger": trigger},
+	})
+}
+
+// fingerprints 설정이 가리키는 파일의 내용 지문 (설정 항목 이름 기준)
+func fingerprints(cfg *config.Config) map[string]string {
+	return map[string]string{
+		"jwt.private_key_file":       fileFingerprint(cfg.JWT.PrivateKeyFile),
+		"webhook.endpoints_file":     fileFingerprint(cfg.Webhook.EndpointsFile),
+		"internal_auth.callers_file": fileFingerprint(cfg.InternalAuth.CallersFile),
+		"server.tls.cert_file":       fileFingerprint(cfg.Server.TLS.CertFile),
+		"server.tls.key_file":        fileFingerprint(cfg.Server.TLS.KeyFile),
+		"server.tls.client_ca_file":  fileFingerprint(cfg.Server.TLS.ClientCAFile),
+	}
+}
+
+func fileFingerprint(path string) string {
+	if path == "" {
+		return ""
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
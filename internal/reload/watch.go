@@ -0,0 +1,128 @@
+package reload
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/signalable/qauth/internal/config"
+)
+
+// debounce 연속된 파일 이벤트를 한 번의 reload로 묶는 대기 시간
+const debounce = 500 * time.Millisecond
+
+// Run SIGHUP 또는 설정/키 파일 변경 시 Reload 실행 (ctx가 취소될 때까지)
+//
+// 파일 자체가 아니라 상위 디렉터리를 감시하므로 rename으로 교체하는 편집기나
+// Kubernetes ConfigMap/Secret의 심볼릭 링크 교체도 감지합니다.
+func (r *Reloader) Run(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	watched := r.watch(watcher, nil)
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	trigger := ""
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-hup:
+			r.Reload(ctx, "sighup")
+			watched = r.watch(watcher, watched)
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !relevant(event.Name, watched) {
+				continue
+			}
+			trigger = "file:" + event.Name
+			timer.Reset(debounce)
+
+		case <-timer.C:
+			r.Reload(ctx, trigger)
+			watched = r.watch(watcher, watched)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			slog.WarnContext(ctx, "설정 파일 감시 에러", slog.Any("error", err))
+		}
+	}
+}
+
+// watch 현재 설정이 가리키는 파일들의 디렉터리를 감시 목록에 추가하고 감시 대상 파일 반환
+func (r *Reloader) watch(watcher *fsnotify.Watcher, previous map[string]bool) map[string]bool {
+	cfg := r.Current()
+	files := map[string]bool{}
+	paths := []string{
+		cfg.ConfigFile,
+		config.EnvFile,
+		cfg.JWT.PrivateKeyFile,
+		cfg.Webhook.EndpointsFile,
+		cfg.InternalAuth.CallersFile,
+		cfg.Server.TLS.CertFile,
+		cfg.Server.TLS.KeyFile,
+		cfg.Server.TLS.ClientCAFile,
+	}
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			continue
+		}
+		files[abs] = true
+
+		dir := filepath.Dir(abs)
+		if previous != nil && watchedDir(previous, dir) {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			slog.Warn("설정 파일 디렉터리 감시 실패", slog.String("dir", dir), slog.Any("error", err))
+		}
+	}
+	return files
+}
+
+// relevant 감시 대상 파일 또는 같은 디렉터리의 Kubernetes 볼륨 교체(..data) 이벤트인지 여부
+func relevant(name string, files map[string]bool) bool {
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return false
+	}
+	if files[abs] {
+		return true
+	}
+	return strings.HasPrefix(filepath.Base(abs), "..data") && watchedDir(files, filepath.Dir(abs))
+}
+
+func watchedDir(files map[string]bool, dir string) bool {
+	for file := range files {
+		if filepath.Dir(file) == dir {
+			return true
+		}
+	}
+	return false
+}
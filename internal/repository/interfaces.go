@@ -22,4 +22,25 @@ type TokenRepository interface {
 
 	// 토큰 새로고침
 	Refresh(ctx context.Context, oldToken string) (*domain.TokenMetadata, error)
+
+	// 사용자 ID로 토큰 메타데이터 조회
+	FindByUserID(ctx context.Context, userID string) (*domain.TokenMetadata, error)
+
+	// 활성 토큰 세션 수 조회
+	CountActive(ctx context.Context) (int64, error)
+
+	// 위임 토큰(토큰 교환으로 발급) 저장 (사용자 전체 로그아웃 시 함께 폐기)
+	StoreDelegated(ctx context.Context, tokenID string, metadata *domain.TokenMetadata) error
+
+	// 위임 토큰 메타데이터 조회 (폐기되었거나 만료되었으면 ErrRevokedToken)
+	FindDelegated(ctx context.Context, tokenID string) (*domain.TokenMetadata, error)
+
+	// 위임 토큰만 폐기 (사용자 세션은 유지)
+	RevokeDelegated(ctx context.Context, tokenID string) error
+
+	// 폐기 기준 시각 갱신 (userID가 비어 있으면 전체, 이 시각 이전에 발급된 토큰은 모두 무효)
+	BumpEpoch(ctx context.Context, userID string) (int64, error)
+
+	// 전체 및 사용자별 폐기 기준 시각 조회 (설정되지 않았으면 0)
+	Epochs(ctx context.Context, userID string) (global int64, user int64, err error)
 }
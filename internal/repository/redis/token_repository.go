@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/logger"
 	"github.com/signalable/qauth/pkg/jwt"
 )
 
@@ -41,8 +43,8 @@ func (r *tokenRepository) Store(ctx context.Context, userID string, metadata *do
 
 // Validate 토큰 검증
 func (r *tokenRepository) Validate(ctx context.Context, token string) (*domain.TokenMetadata, error) {
-	// Redis에서 토큰 조회 전 로깅 추가
-	log.Printf("Validating token in Redis: %s", token)
+	// Redis에서 토큰 조회 전 로깅 추가 (토큰 원문 대신 지문만 기록)
+	slog.DebugContext(ctx, "Redis 토큰 검증", logger.Token(token))
 
 	// JWT에서 userID를 추출
 	userID, err := r.jwtService.ValidateToken(token)
@@ -50,7 +52,11 @@ func (r *tokenRepository) Validate(ctx context.Context, token string) (*domain.T
 		return nil, err
 	}
 
-	// 토큰 메타데이터 조회
+	return r.FindByUserID(ctx, userID)
+}
+
+// FindByUserID 사용자 ID로 토큰 메타데이터 조회
+func (r *tokenRepository) FindByUserID(ctx context.Context, userID string) (*domain.TokenMetadata, error) {
 	data, err := r.client.Get(ctx, fmt.Sprintf("user:%s:token", userID)).Bytes()
 	if err == redis.Nil {
 		return nil, domain.ErrInvalidToken
@@ -80,7 +86,7 @@ func (r *tokenRepository) Revoke(ctx context.Context, token string) error {
 	}
 
 	// 토큰 삭제
-	if err := r.client.Del(ctx, token).Err(); err != nil {
+	if err := r.client.Del(ctx, token, fmt.Sprintf("user:%s:token", metadata.UserID)).Err(); err != nil {
 		return fmt.Errorf("토큰 삭제 실패: %w", err)
 	}
 
@@ -107,8 +113,8 @@ func (r *tokenRepository) RevokeAll(ctx context.Context, userID string) error {
 		}
 	}
 
-	// 토큰 목록 삭제
-	if err := r.client.Del(ctx, fmt.Sprintf("user:%s:tokens", userID)).Err(); err != nil {
+	// 토큰 목록 및 현재 토큰 메타데이터 삭제
+	if err := r.client.Del(ctx, fmt.Sprintf("user:%s:tokens", userID), fmt.Sprintf("user:%s:token", userID)).Err(); err != nil {
 		return fmt.Errorf("토큰 목록 삭제 실패: %w", err)
 	}
 
@@ -122,6 +128,11 @@ func (r *tokenRepository) Refresh(ctx context.Context, oldToken string) (*domain
 		return nil, err
 	}
 
+	// 이전 토큰 폐기
+	if err := r.Revoke(ctx, oldToken); err != nil {
+		return nil, err
+	}
+
 	// 새로운 만료 시간 설정
 	metadata.IssuedAt = time.Now().Unix()
 	metadata.ExpiresAt = time.Now().Add(24 * time.Hour).Unix()
@@ -131,10 +142,130 @@ func (r *tokenRepository) Refresh(ctx context.Context, oldToken string) (*domain
 		return nil, err
 	}
 
-	// 이전 토큰 폐기
-	if err := r.Revoke(ctx, oldToken); err != nil {
-		return nil, err
+	return metadata, nil
+}
+
+// CountActive 활성 토큰 세션 수 조회 (SCAN 기반이라 KEYS와 달리 Redis를 블로킹하지 않음)
+func (r *tokenRepository) CountActive(ctx context.Context) (int64, error) {
+	var count int64
+	iter := r.client.Scan(ctx, 0, "user:*:token", 1000).Iterator()
+	for iter.Next(ctx) {
+		count++
+	}
+	if err := iter.Err(); err != nil {
+		return 0, fmt.Errorf("세션 수 조회 실패: %w", err)
+	}
+	return count, nil
+}
+
+// StoreDelegated 위임 토큰 저장
+//
+// 사용자의 토큰 목록에 함께 넣어 RevokeAll이 위임 토큰도 폐기하도록 합니다.
+func (r *tokenRepository) StoreDelegated(ctx context.Context, tokenID string, metadata *domain.TokenMetadata) error {
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("위임 토큰 메타데이터 직렬화 실패: %w", err)
 	}
 
-	return metadata, nil
+	key := delegatedKey(tokenID)
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, key, data, time.Until(time.Unix(metadata.ExpiresAt, 0)))
+	pipe.SAdd(ctx, fmt.Sprintf("user:%s:tokens", metadata.UserID), key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("위임 토큰 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// FindDelegated 위임 토큰 메타데이터 조회
+func (r *tokenRepository) FindDelegated(ctx context.Context, tokenID string) (*domain.TokenMetadata, error) {
+	data, err := r.client.Get(ctx, delegatedKey(tokenID)).Bytes()
+	if err == redis.Nil {
+		return nil, domain.ErrRevokedToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("위임 토큰 조회 실패: %w", err)
+	}
+
+	var metadata domain.TokenMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("위임 토큰 메타데이터 역직렬화 실패: %w", err)
+	}
+	return &metadata, nil
+}
+
+// RevokeDelegated 위임 토큰 폐기
+func (r *tokenRepository) RevokeDelegated(ctx context.Context, tokenID string) error {
+	metadata, err := r.FindDelegated(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+
+	key := delegatedKey(tokenID)
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.SRem(ctx, fmt.Sprintf("user:%s:tokens", metadata.UserID), key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("위임 토큰 삭제 실패: %w", err)
+	}
+	return nil
+}
+
+// BumpEpoch 폐기 기준 시각을 현재 시각(초)으로 갱신
+//
+// 기준 시각은 되돌아가지 않으며, 같은 초에 발급된 토큰도 무효가 되도록 검증 측은 iat <= epoch를 거부합니다.
+func (r *tokenRepository) BumpEpoch(ctx context.Context, userID string) (int64, error) {
+	epoch, err := bumpEpochScript.Run(ctx, r.client, []string{epochKey(userID)}, time.Now().Unix()).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("폐기 기준 시각 갱신 실패: %w", err)
+	}
+	return epoch, nil
+}
+
+// Epochs 전체 및 사용자별 폐기 기준 시각 조회
+func (r *tokenRepository) Epochs(ctx context.Context, userID string) (int64, int64, error) {
+	keys := []string{epochKey("")}
+	if userID != "" {
+		keys = append(keys, epochKey(userID))
+	}
+	values, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return 0, 0, fmt.Errorf("폐기 기준 시각 조회 실패: %w", err)
+	}
+
+	epochs := make([]int64, 2)
+	for i, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		epoch, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("폐기 기준 시각 형식 오류 (%s): %w", keys[i], err)
+		}
+		epochs[i] = epoch
+	}
+	return epochs[0], epochs[1], nil
+}
+
+// bumpEpochScript 기존 값보다 클 때만 기준 시각 갱신 후 현재 값 반환
+var bumpEpochScript = redis.NewScript(`
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+local epoch = tonumber(ARGV[1])
+if epoch > current then
+  redis.call('SET', KEYS[1], epoch)
+  return epoch
+end
+return current
+`)
+
+func epochKey(userID string) string {
+	if userID == "" {
+		return "revocation:epoch"
+	}
+	return fmt.Sprintf("user:%s:epoch", userID)
+}
+
+func delegatedKey(tokenID string) string {
+	return "delegated:" + tokenID
 }
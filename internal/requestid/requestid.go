@@ -0,0 +1,32 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Header 요청 ID 전달 헤더
+const Header = "X-Request-ID"
+
+type contextKey struct{}
+
+// New 새 요청 ID 생성
+func New() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(buf)
+}
+
+// WithContext context에 요청 ID 추가
+func WithContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext context에서 요청 ID 조회 (없으면 빈 문자열)
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
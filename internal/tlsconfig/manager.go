@@ -0,0 +1,124 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/signalable/qauth/internal/config"
+)
+
+// state 한 시점의 서버 인증서와 클라이언트 CA
+type state struct {
+	cert       tls.Certificate
+	clientCAs  *x509.CertPool
+	identities map[string]string
+}
+
+// Manager 서버 인증서와 클라이언트 CA를 보관하고 핸드셰이크마다 최신 값을 제공
+//
+// 인증서를 교체해도 기존 연결은 유지되고 새 핸드셰이크부터 새 인증서가 사용됩니다.
+type Manager struct {
+	state atomic.Pointer[state]
+}
+
+// NewManager 설정의 인증서 파일로 Manager 생성
+func NewManager(cfg config.TLSConfig) (*Manager, error) {
+	st, err := load(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manager{}
+	m.state.Store(st)
+	return m, nil
+}
+
+// Prepare 새 인증서 파일을 읽고 교체 함수 반환 (reload 적용용)
+func (m *Manager) Prepare(cfg config.TLSConfig) (func(), error) {
+	st, err := load(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return func() { m.state.Store(st) }, nil
+}
+
+// MutualTLS 클라이언트 CA가 설정되어 클라이언트 인증서를 검증하는지 여부
+func (m *Manager) MutualTLS() bool {
+	return m != nil && m.state.Load().clientCAs != nil
+}
+
+// TLSConfig 핸드셰이크마다 최신 인증서/CA를 쓰는 서버 TLS 설정
+//
+// 공개 API도 같은 포트를 쓰므로 클라이언트 인증서는 제시된 경우에만 검증하고,
+// 내부 API에서 인증서 요구 여부는 라우트 단위로 확인합니다.
+func (m *Manager) TLSConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			st := m.state.Load()
+			cfg := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   nextProtos,
+				Certificates: []tls.Certificate{st.cert},
+			}
+			if st.clientCAs != nil {
+				cfg.ClientAuth = tls.VerifyClientCertIfGiven
+				cfg.ClientCAs = st.clientCAs
+			}
+			return cfg, nil
+		},
+	}
+}
+
+// Identity 검증된 클라이언트 인증서의 호출자 식별자
+//
+// client_identities에서 전체 subject(예: "CN=user-service,O=internal"), CN 순으로 찾고
+// 없으면 CN을 그대로 사용합니다.
+func (m *Manager) Identity(cert *x509.Certificate) string {
+	identities := m.state.Load().identities
+	if id, ok := identities[cert.Subject.String()]; ok {
+		return id
+	}
+	if id, ok := identities[cert.Subject.CommonName]; ok {
+		return id
+	}
+	return cert.Subject.CommonName
+}
+
+// VerifiedIdentity TLS 연결 상태에서 검증된 클라이언트 인증서의 호출자 식별자 (없으면 빈 문자열)
+func (m *Manager) VerifiedIdentity(cs *tls.ConnectionState) string {
+	if m == nil || cs == nil || len(cs.VerifiedChains) == 0 || len(cs.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return m.Identity(cs.VerifiedChains[0][0])
+}
+
+func load(cfg config.TLSConfig) (*state, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("TLS 인증서 로드 실패: %w", err)
+	}
+
+	st := &state{
+		cert:       cert,
+		identities: cfg.ClientIdentities,
+	}
+
+	if cfg.ClientCAFile != "" {
+		data, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("클라이언트 CA 읽기 실패: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, errors.New("클라이언트 CA 파일에 PEM 인증서가 없습니다")
+		}
+		st.clientCAs = pool
+	}
+	return st, nil
+}
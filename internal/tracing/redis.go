@@ -0,0 +1,79 @@
+package tracing
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// redisHook Redis 명령마다 client span을 생성하는 go-redis 훅
+//
+// 명령 인자에는 토큰 등 민감 정보가 포함될 수 있으므로 명령 이름만 기록합니다.
+type redisHook struct {
+	attrs []attribute.KeyValue
+}
+
+// RedisHook Redis 클라이언트에 등록할 tracing 훅
+func RedisHook(opts *redis.Options) redis.Hook {
+	return &redisHook{
+		attrs: []attribute.KeyValue{
+			semconv.DBSystemRedis,
+			semconv.DBNamespace(strconv.Itoa(opts.DB)),
+			semconv.ServerAddress(opts.Addr),
+		},
+	}
+}
+
+func (h *redisHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	ctx, _ = Tracer().Start(ctx, "redis "+cmd.Name(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(h.attrs...),
+		trace.WithAttributes(semconv.DBOperationName(cmd.Name())),
+	)
+	return ctx, nil
+}
+
+func (h *redisHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	h.end(ctx, []redis.Cmder{cmd})
+	return nil
+}
+
+func (h *redisHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	names := make([]string, len(cmds))
+	for i, cmd := range cmds {
+		names[i] = cmd.Name()
+	}
+
+	ctx, _ = Tracer().Start(ctx, "redis pipeline",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(h.attrs...),
+		trace.WithAttributes(
+			semconv.DBOperationName(strings.Join(names, " ")),
+			attribute.Int("db.operation.batch.size", len(cmds)),
+		),
+	)
+	return ctx, nil
+}
+
+func (h *redisHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	h.end(ctx, cmds)
+	return nil
+}
+
+func (h *redisHook) end(ctx context.Context, cmds []redis.Cmder) {
+	span := trace.SpanFromContext(ctx)
+	for _, cmd := range cmds {
+		if err := cmd.Err(); err != nil && err != redis.Nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			break
+		}
+	}
+	span.End()
+}
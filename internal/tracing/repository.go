@@ -0,0 +1,110 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/repository"
+)
+
+type tracedTokenRepository struct {
+	next repository.TokenRepository
+}
+
+// InstrumentTokenRepository 메서드마다 span을 생성하는 TokenRepository 래퍼
+func InstrumentTokenRepository(next repository.TokenRepository) repository.TokenRepository {
+	return &tracedTokenRepository{next: next}
+}
+
+func (r *tracedTokenRepository) Store(ctx context.Context, userID string, metadata *domain.TokenMetadata) error {
+	ctx, span := Tracer().Start(ctx, "tokenRepository.Store")
+	span.SetAttributes(attribute.String("enduser.id", userID))
+	err := r.next.Store(ctx, userID, metadata)
+	End(span, err)
+	return err
+}
+
+func (r *tracedTokenRepository) Validate(ctx context.Context, token string) (*domain.TokenMetadata, error) {
+	ctx, span := Tracer().Start(ctx, "tokenRepository.Validate")
+	metadata, err := r.next.Validate(ctx, token)
+	End(span, err)
+	return metadata, err
+}
+
+func (r *tracedTokenRepository) Revoke(ctx context.Context, token string) error {
+	ctx, span := Tracer().Start(ctx, "tokenRepository.Revoke")
+	err := r.next.Revoke(ctx, token)
+	End(span, err)
+	return err
+}
+
+func (r *tracedTokenRepository) RevokeAll(ctx context.Context, userID string) error {
+	ctx, span := Tracer().Start(ctx, "tokenRepository.RevokeAll")
+	span.SetAttributes(attribute.String("enduser.id", userID))
+	err := r.next.RevokeAll(ctx, userID)
+	End(span, err)
+	return err
+}
+
+func (r *tracedTokenRepository) Refresh(ctx context.Context, oldToken string) (*domain.TokenMetadata, error) {
+	ctx, span := Tracer().Start(ctx, "tokenRepository.Refresh")
+	metadata, err := r.next.Refresh(ctx, oldToken)
+	End(span, err)
+	return metadata, err
+}
+
+func (r *tracedTokenRepository) FindByUserID(ctx context.Context, userID string) (*domain.TokenMetadata, error) {
+	ctx, span := Tracer().Start(ctx, "tokenRepository.FindByUserID")
+	span.SetAttributes(attribute.String("enduser.id", userID))
+	metadata, err := r.next.FindByUserID(ctx, userID)
+	End(span, err)
+	return metadata, err
+}
+
+func (r *tracedTokenRepository) CountActive(ctx context.Context) (int64, error) {
+	ctx, span := Tracer().Start(ctx, "tokenRepository.CountActive")
+	count, err := r.next.CountActive(ctx)
+	End(span, err)
+	return count, err
+}
+
+func (r *tracedTokenRepository) StoreDelegated(ctx context.Context, tokenID string, metadata *domain.TokenMetadata) error {
+	ctx, span := Tracer().Start(ctx, "tokenRepository.StoreDelegated")
+	span.SetAttributes(attribute.String("enduser.id", metadata.UserID))
+	err := r.next.StoreDelegated(ctx, tokenID, metadata)
+	End(span, err)
+	return err
+}
+
+func (r *tracedTokenRepository) FindDelegated(ctx context.Context, tokenID string) (*domain.TokenMetadata, error) {
+	ctx, span := Tracer().Start(ctx, "tokenRepository.FindDelegated")
+	metadata, err := r.next.FindDelegated(ctx, tokenID)
+	End(span, err)
+	return metadata, err
+}
+
+func (r *tracedTokenRepository) RevokeDelegated(ctx context.Context, tokenID string) error {
+	ctx, span := Tracer().Start(ctx, "tokenRepository.RevokeDelegated")
+	err := r.next.RevokeDelegated(ctx, tokenID)
+	End(span, err)
+	return err
+}
+
+func (r *tracedTokenRepository) BumpEpoch(ctx context.Context, userID string) (int64, error) {
+	ctx, span := Tracer().Start(ctx, "tokenRepository.BumpEpoch")
+	if userID != "" {
+		span.SetAttributes(attribute.String("enduser.id", userID))
+	}
+	epoch, err := r.next.BumpEpoch(ctx, userID)
+	End(span, err)
+	return epoch, err
+}
+
+func (r *tracedTokenRepository) Epochs(ctx context.Context, userID string) (int64, int64, error) {
+	ctx, span := Tracer().Start(ctx, "tokenRepository.Epochs")
+	global, user, err := r.next.Epochs(ctx, userID)
+	End(span, err)
+	return global, user, err
+}
@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/usecase"
+)
+
+type tracedAuthUseCase struct {
+	next usecase.AuthUseCase
+}
+
+// InstrumentAuthUseCase 메서드마다 span을 생성하는 AuthUseCase 래퍼
+func InstrumentAuthUseCase(next usecase.AuthUseCase) usecase.AuthUseCase {
+	return &tracedAuthUseCase{next: next}
+}
+
+func (uc *tracedAuthUseCase) CreateToken(ctx context.Context, userID string) (*domain.AuthResponse, error) {
+	ctx, span := Tracer().Start(ctx, "authUseCase.CreateToken")
+	span.SetAttributes(attribute.String("enduser.id", userID))
+	resp, err := uc.next.CreateToken(ctx, userID)
+	End(span, err)
+	return resp, err
+}
+
+func (uc *tracedAuthUseCase) ValidateToken(ctx context.Context, token string) (*domain.TokenValidationResponse, error) {
+	ctx, span := Tracer().Start(ctx, "authUseCase.ValidateToken")
+	resp, err := uc.next.ValidateToken(ctx, token)
+	if err == nil {
+		span.SetAttributes(attribute.String("enduser.id", resp.UserID))
+	}
+	End(span, err)
+	return resp, err
+}
+
+func (uc *tracedAuthUseCase) RevokeToken(ctx context.Context, token string) error {
+	ctx, span := Tracer().Start(ctx, "authUseCase.RevokeToken")
+	err := uc.next.RevokeToken(ctx, token)
+	End(span, err)
+	return err
+}
+
+func (uc *tracedAuthUseCase) RevokeAllTokens(ctx context.Context, userID string) error {
+	ctx, span := Tracer().Start(ctx, "authUseCase.RevokeAllTokens")
+	span.SetAttributes(attribute.String("enduser.id", userID))
+	err := uc.next.RevokeAllTokens(ctx, userID)
+	End(span, err)
+	return err
+}
+
+func (uc *tracedAuthUseCase) RefreshToken(ctx context.Context, oldToken string) (*domain.AuthResponse, error) {
+	ctx, span := Tracer().Start(ctx, "authUseCase.RefreshToken")
+	resp, err := uc.next.RefreshToken(ctx, oldToken)
+	End(span, err)
+	return resp, err
+}
+
+func (uc *tracedAuthUseCase) GetTokenMetadata(ctx context.Context, token string) (*domain.TokenMetadata, error) {
+	ctx, span := Tracer().Start(ctx, "authUseCase.GetTokenMetadata")
+	metadata, err := uc.next.GetTokenMetadata(ctx, token)
+	End(span, err)
+	return metadata, err
+}
+
+type tracedTokenExchangeUseCase struct {
+	next usecase.TokenExchangeUseCase
+}
+
+// InstrumentTokenExchangeUseCase span을 생성하는 TokenExchangeUseCase 래퍼
+func InstrumentTokenExchangeUseCase(next usecase.TokenExchangeUseCase) usecase.TokenExchangeUseCase {
+	return &tracedTokenExchangeUseCase{next: next}
+}
+
+func (uc *tracedTokenExchangeUseCase) ExchangeToken(ctx context.Context, req *domain.TokenExchangeRequest) (*domain.TokenExchangeResponse, error) {
+	ctx, span := Tracer().Start(ctx, "tokenExchangeUseCase.ExchangeToken")
+	span.SetAttributes(attribute.String("qauth.exchange.audience", req.Audience))
+	resp, err := uc.next.ExchangeToken(ctx, req)
+	End(span, err)
+	return resp, err
+}
@@ -2,33 +2,60 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel"
+
+	"github.com/signalable/qauth/internal/caller"
 	"github.com/signalable/qauth/internal/domain"
 	"github.com/signalable/qauth/internal/repository"
 	"github.com/signalable/qauth/pkg/jwt"
 )
 
+// 발급 토큰 종류 (DPoP 바인딩 토큰은 Authorization: DPoP으로 사용)
+const (
+	tokenTypeBearer = "Bearer"
+	tokenTypeDPoP   = "DPoP"
+)
+
 type authUseCase struct {
 	tokenRepo  repository.TokenRepository
 	jwtService *jwt.Service
+	proofs     ProofVerifier
+	apiKeys    APIKeyAuthenticator
 }
 
 // NewAuthUseCase Auth 유스케이스 생성자
+//
+// proofs가 nil이면 DPoP 바인딩 없이 발급하고 바인딩된 토큰은 거부하며,
+// apiKeys가 nil이면 API 키를 JWT로 취급해 거부합니다.
 func NewAuthUseCase(
 	tokenRepo repository.TokenRepository,
 	jwtService *jwt.Service,
+	proofs ProofVerifier,
+	apiKeys APIKeyAuthenticator,
 ) AuthUseCase {
 	return &authUseCase{
 		tokenRepo:  tokenRepo,
 		jwtService: jwtService,
+		proofs:     proofs,
+		apiKeys:    apiKeys,
 	}
 }
 
 // CreateToken 토큰 생성
+//
+// 요청에 DPoP 증명이 있으면 증명 키의 thumbprint를 cnf.jkt로 바인딩하고 (RFC 9449),
+// 인증서 바인딩이 설정된 호출자면 클라이언트 인증서 thumbprint를 cnf.x5t#S256으로 바인딩합니다 (RFC 8705).
 func (uc *authUseCase) CreateToken(ctx context.Context, userID string) (*domain.AuthResponse, error) {
+	confirmation, err := uc.confirmation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// JWT 토큰 생성
-	tokenString, err := uc.jwtService.GenerateToken(userID)
+	tokenString, err := uc.generateToken(userID, confirmation)
 	if err != nil {
 		return nil, err
 	}
@@ -48,34 +75,81 @@ func (uc *authUseCase) CreateToken(ctx context.Context, userID string) (*domain.
 
 	return &domain.AuthResponse{
 		AccessToken: tokenString,
-		TokenType:   "Bearer",
+		TokenType:   tokenType(confirmation),
 		ExpiresIn:   metadata.ExpiresAt - metadata.IssuedAt,
 	}, nil
 }
 
 // ValidateToken 토큰 검증
+//
+// 키에 바인딩된 토큰은 이 요청에서 같은 키의 소유가 증명되어야 유효합니다 (DPoP 증명 또는 같은 클라이언트 인증서).
+// API 키도 같은 방식으로 검증하며 키의 소유자와 scope를 응답합니다.
 func (uc *authUseCase) ValidateToken(ctx context.Context, token string) (*domain.TokenValidationResponse, error) {
-	// JWT 토큰 검증
-	_, err := uc.jwtService.ValidateToken(token)
+	if uc.apiKeys != nil {
+		key, found, err := uc.apiKeys.Authenticate(ctx, token)
+		if found {
+			if err != nil {
+				return &domain.TokenValidationResponse{Valid: false}, err
+			}
+			return &domain.TokenValidationResponse{
+				Valid:    true,
+				UserID:   key.UserID,
+				Scopes:   key.Scopes,
+				APIKeyID: key.ID,
+			}, nil
+		}
+	}
+
+	// JWT 토큰 검증 (Redis 조회와 구분되도록 별도 span)
+	_, span := otel.Tracer("github.com/signalable/qauth").Start(ctx, "jwt.ValidateToken")
+	claims, err := uc.jwtService.ValidateClaims(token)
+	span.End()
+	if err != nil {
+		return &domain.TokenValidationResponse{Valid: false}, tokenError(err)
+	}
+
+	// Redis에서 토큰 메타데이터 검증 (위임 토큰은 사용자 세션과 별도로 관리)
+	metadata, err := uc.findMetadata(ctx, token, claims)
 	if err != nil {
+		return &domain.TokenValidationResponse{Valid: false}, tokenError(err)
+	}
+
+	// 소유 증명 확인
+	confirmation := boundConfirmation(claims)
+	if err := uc.verifyBinding(ctx, confirmation, token); err != nil {
+		return &domain.TokenValidationResponse{Valid: false}, err
+	}
+
+	if err := uc.checkAudience(ctx, claims); err != nil {
 		return &domain.TokenValidationResponse{Valid: false}, err
 	}
 
-	// Redis에서 토큰 메타데이터 검증
-	metadata, err := uc.tokenRepo.Validate(ctx, token)
+	actor, err := actorClaim(claims)
 	if err != nil {
 		return &domain.TokenValidationResponse{Valid: false}, err
 	}
 
 	return &domain.TokenValidationResponse{
-		Valid:  true,
-		UserID: metadata.UserID,
+		Valid:         true,
+		UserID:        metadata.UserID,
+		Confirmation:  confirmation,
+		Scopes:        scopeClaim(claims),
+		Audience:      audienceClaim(claims),
+		Actor:         actor,
+		Impersonation: claims[domain.ClaimImpersonation] == true,
 	}, nil
 }
 
-// RevokeToken 토큰 폐기
+// RevokeToken 토큰 폐기 (위임 토큰은 그 토큰만 폐기하고 사용자 세션은 유지)
 func (uc *authUseCase) RevokeToken(ctx context.Context, token string) error {
-	return uc.tokenRepo.Revoke(ctx, token)
+	claims, err := uc.jwtService.ValidateClaims(token)
+	if err != nil {
+		return tokenError(err)
+	}
+	if tokenID, ok := delegatedTokenID(claims); ok {
+		return uc.tokenRepo.RevokeDelegated(ctx, tokenID)
+	}
+	return tokenError(uc.tokenRepo.Revoke(ctx, token))
 }
 
 // RevokeAllTokens 사용자의 모든 토큰 폐기
@@ -84,27 +158,203 @@ func (uc *authUseCase) RevokeAllTokens(ctx context.Context, userID string) error
 }
 
 // RefreshToken 토큰 새로고침
+//
+// 키에 바인딩된 토큰은 소유 증명을 확인한 뒤 새 토큰도 같은 키에 바인딩합니다.
 func (uc *authUseCase) RefreshToken(ctx context.Context, oldToken string) (*domain.AuthResponse, error) {
+	// 이전 토큰이 폐기되기 전에 소유 증명 확인
+	claims, err := uc.jwtService.ValidateClaims(oldToken)
+	if err != nil {
+		return nil, tokenError(err)
+	}
+	if _, ok := delegatedTokenID(claims); ok {
+		return nil, fmt.Errorf("%w: 위임 토큰은 갱신할 수 없습니다", domain.ErrInvalidRequest)
+	}
+	if err := uc.checkEpoch(ctx, claims); err != nil {
+		return nil, err
+	}
+	confirmation := boundConfirmation(claims)
+	if err := uc.verifyBinding(ctx, confirmation, oldToken); err != nil {
+		return nil, err
+	}
+
 	// Redis에서 토큰 새로고침
 	metadata, err := uc.tokenRepo.Refresh(ctx, oldToken)
 	if err != nil {
-		return nil, err
+		return nil, tokenError(err)
 	}
 
 	// 새로운 JWT 토큰 생성
-	tokenString, err := uc.jwtService.GenerateToken(metadata.UserID)
+	tokenString, err := uc.generateToken(metadata.UserID, confirmation)
 	if err != nil {
 		return nil, err
 	}
 
 	return &domain.AuthResponse{
 		AccessToken: tokenString,
-		TokenType:   "Bearer",
+		TokenType:   tokenType(confirmation),
 		ExpiresIn:   metadata.ExpiresAt - metadata.IssuedAt,
 	}, nil
 }
 
 // GetTokenMetadata 토큰 메타데이터 조회
 func (uc *authUseCase) GetTokenMetadata(ctx context.Context, token string) (*domain.TokenMetadata, error) {
+	claims, err := uc.jwtService.ValidateClaims(token)
+	if err != nil {
+		return nil, tokenError(err)
+	}
+	metadata, err := uc.findMetadata(ctx, token, claims)
+	if err != nil {
+		return nil, tokenError(err)
+	}
+	return metadata, nil
+}
+
+// findMetadata 토큰 메타데이터 조회 (위임 토큰은 jti로 저장된 기록을 조회)
+func (uc *authUseCase) findMetadata(ctx context.Context, token string, claims map[string]interface{}) (*domain.TokenMetadata, error) {
+	if err := uc.checkEpoch(ctx, claims); err != nil {
+		return nil, err
+	}
+	if tokenID, ok := delegatedTokenID(claims); ok {
+		return uc.tokenRepo.FindDelegated(ctx, tokenID)
+	}
 	return uc.tokenRepo.Validate(ctx, token)
 }
+
+// checkEpoch 전체 또는 사용자별 폐기 기준 시각 이전에 발급된 토큰 거부 (qauthctl epoch bump)
+//
+// 기준 시각과 같은 초에 발급된 토큰도 거부합니다. iat가 없는 토큰은 기준 시각이 있으면 거부됩니다.
+func (uc *authUseCase) checkEpoch(ctx context.Context, claims map[string]interface{}) error {
+	userID, _ := claims["user_id"].(string)
+	global, user, err := uc.tokenRepo.Epochs(ctx, userID)
+	if err != nil {
+		return err
+	}
+	epoch := global
+	if user > epoch {
+		epoch = user
+	}
+	if epoch == 0 {
+		return nil
+	}
+
+	iat, _ := claims["iat"].(float64)
+	if int64(iat) <= epoch {
+		return fmt.Errorf("%w: 폐기 기준 시각 이전에 발급된 토큰입니다", domain.ErrRevokedToken)
+	}
+	return nil
+}
+
+// generateToken JWT 토큰 생성 (confirmation이 있으면 cnf claim 추가)
+func (uc *authUseCase) generateToken(userID string, confirmation *domain.Confirmation) (string, error) {
+	if confirmation == nil {
+		return uc.jwtService.GenerateToken(userID)
+	}
+	return uc.jwtService.GenerateToken(userID, jwt.WithClaim("cnf", confirmation))
+}
+
+// confirmation 발급할 토큰의 키 바인딩 (바인딩하지 않으면 nil)
+func (uc *authUseCase) confirmation(ctx context.Context) (*domain.Confirmation, error) {
+	var confirmation domain.Confirmation
+	if proof, ok := domain.ProofFromContext(ctx); ok && uc.proofs != nil {
+		jkt, err := uc.proofs.Verify(ctx, proof, "")
+		if err != nil {
+			return nil, err
+		}
+		confirmation.JKT = jkt
+	}
+	if c := caller.FromContext(ctx); c != nil && c.BindTokensToCertificate {
+		confirmation.X5T = domain.CertificateThumbprintFromContext(ctx)
+		if confirmation.X5T == "" {
+			return nil, fmt.Errorf("%w: 인증서 바인딩 토큰은 클라이언트 인증서가 필요합니다", domain.ErrInvalidRequest)
+		}
+	}
+
+	if confirmation == (domain.Confirmation{}) {
+		return nil, nil
+	}
+	return &confirmation, nil
+}
+
+// verifyBinding 토큰이 바인딩된 키의 소유가 이 요청에서 증명되었는지 확인 (바인딩 없는 토큰은 통과)
+//
+// DPoP 바인딩은 같은 키로 서명한 증명이, 인증서 바인딩은 같은 클라이언트 인증서가 필요합니다.
+// DPoP 바인딩이 없는 토큰을 DPoP scheme으로 제시하면 거부합니다 (RFC 9449 7.1).
+func (uc *authUseCase) verifyBinding(ctx context.Context, confirmation *domain.Confirmation, token string) error {
+	if confirmation == nil || confirmation.JKT == "" {
+		if proof, ok := domain.ProofFromContext(ctx); ok && proof.Scheme {
+			return fmt.Errorf("%w: DPoP에 바인딩되지 않은 토큰입니다", domain.ErrInvalidToken)
+		}
+	}
+	if confirmation == nil {
+		return nil
+	}
+
+	if confirmation.X5T != "" && domain.CertificateThumbprintFromContext(ctx) != confirmation.X5T {
+		return fmt.Errorf("%w: 토큰에 바인딩된 인증서와 다릅니다", domain.ErrInvalidToken)
+	}
+
+	if confirmation.JKT != "" {
+		proof, ok := domain.ProofFromContext(ctx)
+		if !ok || uc.proofs == nil {
+			return fmt.Errorf("%w: DPoP 증명이 필요합니다", domain.ErrInvalidToken)
+		}
+		jkt, err := uc.proofs.Verify(ctx, proof, token)
+		if err != nil {
+			return err
+		}
+		if jkt != confirmation.JKT {
+			return fmt.Errorf("%w: 토큰에 바인딩된 키와 다릅니다", domain.ErrInvalidDPoPProof)
+		}
+	}
+	return nil
+}
+
+// checkAudience 토큰 aud가 이 검증 요청에서 허용하는 대상인지 확인
+//
+// aud가 없거나 qauth 기본 audience인 일반 토큰은 항상 통과합니다. 그 외(교환 토큰)는 프록시 원 요청 경로별
+// 규칙이 있으면 그 aud에, 없으면 검증을 요청한 내부 호출자의 audiences에 있어야 하므로
+// 다른 서비스용으로 교환한 토큰은 거부됩니다.
+func (uc *authUseCase) checkAudience(ctx context.Context, claims map[string]interface{}) error {
+	audience := audienceClaim(claims)
+	if len(audience) == 0 {
+		return nil
+	}
+
+	allowed, ok := domain.ExpectedAudienceFromContext(ctx)
+	if !ok {
+		if c := caller.FromContext(ctx); c != nil {
+			allowed = c.Audiences
+		}
+	}
+	if defaultAudience := uc.jwtService.Audience(); defaultAudience != "" {
+		allowed = append([]string{defaultAudience}, allowed...)
+	}
+
+	for _, aud := range audience {
+		for _, want := range allowed {
+			if aud == want {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%w: 토큰 대상(aud)이 일치하지 않습니다", domain.ErrInvalidToken)
+}
+
+// boundConfirmation 토큰 claims의 키 바인딩 (없으면 nil)
+func boundConfirmation(claims map[string]interface{}) *domain.Confirmation {
+	cnf, _ := claims["cnf"].(map[string]interface{})
+	jkt, _ := cnf["jkt"].(string)
+	x5t, _ := cnf["x5t#S256"].(string)
+	if jkt == "" && x5t == "" {
+		return nil
+	}
+	return &domain.Confirmation{JKT: jkt, X5T: x5t}
+}
+
+// tokenType 발급 응답의 token_type (인증서 바인딩만 있으면 RFC 8705에 따라 Bearer)
+func tokenType(confirmation *domain.Confirmation) string {
+	if confirmation != nil && confirmation.JKT != "" {
+		return tokenTypeDPoP
+	}
+	return tokenTypeBearer
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/pkg/jwt"
+)
+
+// tokenError JWT 검증 에러를 domain 에러로 변환 (Redis 장애 등 그 외 에러는 그대로 반환)
+func tokenError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, jwt.ErrExpiredToken):
+		return fmt.Errorf("%w: %v", domain.ErrExpiredToken, err)
+	case errors.Is(err, jwt.ErrInvalidToken):
+		return fmt.Errorf("%w: %v", domain.ErrInvalidToken, err)
+	default:
+		return err
+	}
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/signalable/qauth/internal/domain"
 )
@@ -26,3 +27,24 @@ type AuthUseCase interface {
 	// 인증 토큰의 메타데이터 조회
 	GetTokenMetadata(ctx context.Context, token string) (*domain.TokenMetadata, error)
 }
+
+// ProofVerifier DPoP 증명 검증 (증명 키의 JWK SHA-256 thumbprint 반환)
+type ProofVerifier interface {
+	Verify(ctx context.Context, proof domain.ProofRequest, accessToken string) (string, error)
+}
+
+// APIKeyAuthenticator API 키 인증 (API 키 형식이 아니면 found가 false)
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, credential string) (key *domain.APIKey, found bool, err error)
+}
+
+// TokenExchangeUseCase 토큰 교환 유스케이스 (RFC 8693)
+type TokenExchangeUseCase interface {
+	// subject 토큰을 대상 서비스 전용 위임 토큰으로 교환
+	ExchangeToken(ctx context.Context, req *domain.TokenExchangeRequest) (*domain.TokenExchangeResponse, error)
+}
+
+// ExchangePolicy 토큰 교환 대상과 scope 결정 (subjectScopes가 nil이면 subject 토큰에 scope 제한 없음)
+type ExchangePolicy interface {
+	Grant(audience string, subjectScopes, requested []string) (scopes []string, ttl time.Duration, err error)
+}
@@ -0,0 +1,232 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/signalable/qauth/internal/caller"
+	"github.com/signalable/qauth/internal/domain"
+	"github.com/signalable/qauth/internal/repository"
+	"github.com/signalable/qauth/pkg/jwt"
+)
+
+type tokenExchangeUseCase struct {
+	authUseCase AuthUseCase
+	tokenRepo   repository.TokenRepository
+	jwtService  *jwt.Service
+	policy      ExchangePolicy
+}
+
+// NewTokenExchangeUseCase 토큰 교환 유스케이스 생성자
+//
+// subject/actor 토큰은 authUseCase로 검증하므로 감사/지표 래퍼를 적용한 유스케이스를 넘기면
+// 교환 과정의 검증 실패도 함께 기록됩니다.
+func NewTokenExchangeUseCase(
+	authUseCase AuthUseCase,
+	tokenRepo repository.TokenRepository,
+	jwtService *jwt.Service,
+	policy ExchangePolicy,
+) TokenExchangeUseCase {
+	return &tokenExchangeUseCase{
+		authUseCase: authUseCase,
+		tokenRepo:   tokenRepo,
+		jwtService:  jwtService,
+		policy:      policy,
+	}
+}
+
+// ExchangeToken subject 토큰을 대상 서비스 전용 위임 토큰으로 교환 (RFC 8693)
+//
+// 발급 토큰은 act claim에 actor(actor_token의 사용자, 없으면 인증된 내부 호출자)를 담고,
+// subject 토큰이 이미 위임 토큰이면 이전 actor를 그 안에 중첩합니다. 유효 시간은 정책의 TTL과
+// subject 토큰의 남은 시간 중 짧은 쪽입니다. 키에 바인딩된 토큰은 이 요청에서 소유 증명을 할 수 없으므로
+// subject 토큰으로 쓸 수 없습니다.
+func (uc *tokenExchangeUseCase) ExchangeToken(ctx context.Context, req *domain.TokenExchangeRequest) (*domain.TokenExchangeResponse, error) {
+	if err := validateExchangeRequest(req); err != nil {
+		return nil, err
+	}
+
+	// subject 토큰 검증
+	subject, err := uc.authUseCase.ValidateToken(ctx, req.SubjectToken)
+	if err != nil {
+		return nil, exchangeTokenError("subject_token", err)
+	}
+	if subject.APIKeyID != "" {
+		return nil, fmt.Errorf("%w: API 키는 subject_token으로 교환할 수 없습니다", domain.ErrInvalidRequest)
+	}
+	claims, err := uc.jwtService.ValidateClaims(req.SubjectToken)
+	if err != nil {
+		return nil, exchangeTokenError("subject_token", tokenError(err))
+	}
+
+	// actor 결정
+	actor, err := uc.actor(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	actor.Actor = subject.Actor
+
+	// 대상과 scope 결정
+	scopes, ttl, err := uc.policy.Grant(req.Audience, subject.Scopes, req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if exp, ok := claims["exp"].(float64); ok {
+		if remaining := time.Until(time.Unix(int64(exp), 0)); remaining < ttl {
+			ttl = remaining
+		}
+	}
+
+	tokenID, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+	scope := strings.Join(scopes, " ")
+	opts := []jwt.TokenOption{
+		jwt.WithClaim("jti", tokenID),
+		jwt.WithClaim("act", actor),
+		jwt.WithClaim("scope", scope),
+		jwt.WithTokenAudience(req.Audience),
+		jwt.WithLifetime(ttl),
+	}
+	if subject.Impersonation {
+		// 대리 로그인 토큰을 교환해도 대상 서비스가 알 수 있도록 표시 유지
+		opts = append(opts, jwt.WithClaim(domain.ClaimImpersonation, true))
+	}
+	tokenString, err := uc.jwtService.GenerateToken(subject.UserID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	metadata := &domain.TokenMetadata{
+		UserID:    subject.UserID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	}
+	if err := uc.tokenRepo.StoreDelegated(ctx, tokenID, metadata); err != nil {
+		return nil, err
+	}
+
+	return &domain.TokenExchangeResponse{
+		AccessToken:     tokenString,
+		IssuedTokenType: domain.TokenTypeAccessToken,
+		TokenType:       tokenTypeBearer,
+		ExpiresIn:       int64(ttl.Seconds()),
+		Scope:           scope,
+	}, nil
+}
+
+// actor 위임 토큰의 actor (actor_token이 있으면 그 사용자, 없으면 인증된 내부 호출자)
+func (uc *tokenExchangeUseCase) actor(ctx context.Context, req *domain.TokenExchangeRequest) (*domain.Actor, error) {
+	if req.ActorToken != "" {
+		resp, err := uc.authUseCase.ValidateToken(ctx, req.ActorToken)
+		if err != nil {
+			return nil, exchangeTokenError("actor_token", err)
+		}
+		return &domain.Actor{Subject: resp.UserID}, nil
+	}
+
+	// 호출자 목록 없이 열어 둔 개발 환경에서는 호출자 ID가 없으므로 actor_token 필요
+	if c := caller.FromContext(ctx); c != nil && c.ID != "" {
+		return &domain.Actor{Subject: c.ID}, nil
+	}
+	return nil, fmt.Errorf("%w: actor_token이 필요합니다", domain.ErrInvalidRequest)
+}
+
+// validateExchangeRequest grant 공통 파라미터 확인 (RFC 8693 2.1)
+func validateExchangeRequest(req *domain.TokenExchangeRequest) error {
+	switch {
+	case req.SubjectToken == "":
+		return fmt.Errorf("%w: subject_token이 필요합니다", domain.ErrInvalidRequest)
+	case !exchangeableTokenType(req.SubjectTokenType):
+		return fmt.Errorf("%w: 지원하지 않는 subject_token_type입니다 (%q)", domain.ErrInvalidRequest, req.SubjectTokenType)
+	case req.ActorToken == "" && req.ActorTokenType != "":
+		return fmt.Errorf("%w: actor_token 없이 actor_token_type을 보낼 수 없습니다", domain.ErrInvalidRequest)
+	case req.ActorToken != "" && !exchangeableTokenType(req.ActorTokenType):
+		return fmt.Errorf("%w: 지원하지 않는 actor_token_type입니다 (%q)", domain.ErrInvalidRequest, req.ActorTokenType)
+	case req.RequestedTokenType != "" && req.RequestedTokenType != domain.TokenTypeAccessToken:
+		return fmt.Errorf("%w: 발급할 수 없는 requested_token_type입니다 (%q)", domain.ErrInvalidRequest, req.RequestedTokenType)
+	case req.Audience == "":
+		return fmt.Errorf("%w: audience가 필요합니다", domain.ErrInvalidTarget)
+	}
+	return nil
+}
+
+func exchangeableTokenType(tokenType string) bool {
+	return tokenType == domain.TokenTypeAccessToken || tokenType == domain.TokenTypeJWT
+}
+
+// exchangeTokenError 교환에 쓴 토큰의 검증 실패를 invalid_request로 변환 (RFC 8693 2.2.2, 내부 에러는 그대로 반환)
+func exchangeTokenError(param string, err error) error {
+	if domain.ErrorCode(err) == domain.CodeInternalError {
+		return err
+	}
+	return fmt.Errorf("%w: %s: %v", domain.ErrInvalidRequest, param, err)
+}
+
+// newTokenID 위임 토큰 식별자 (jti)
+func newTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("토큰 ID 생성 실패: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// delegatedTokenID 위임 토큰(act claim 포함)이면 jti 반환
+func delegatedTokenID(claims map[string]interface{}) (string, bool) {
+	if _, ok := claims["act"]; !ok {
+		return "", false
+	}
+	tokenID, _ := claims["jti"].(string)
+	return tokenID, true
+}
+
+// actorClaim 토큰 claims의 act (없으면 nil)
+func actorClaim(claims map[string]interface{}) (*domain.Actor, error) {
+	act, ok := claims["act"]
+	if !ok {
+		return nil, nil
+	}
+	data, err := json.Marshal(act)
+	if err != nil {
+		return nil, fmt.Errorf("%w: act claim: %v", domain.ErrInvalidToken, err)
+	}
+	var actor domain.Actor
+	if err := json.Unmarshal(data, &actor); err != nil || actor.Subject == "" {
+		return nil, fmt.Errorf("%w: act claim 형식이 잘못되었습니다", domain.ErrInvalidToken)
+	}
+	return &actor, nil
+}
+
+// scopeClaim 토큰 claims의 scope (공백 구분, 없으면 nil)
+func scopeClaim(claims map[string]interface{}) []string {
+	scope, _ := claims["scope"].(string)
+	if scope == "" {
+		return nil
+	}
+	return strings.Fields(scope)
+}
+
+// audienceClaim 토큰 claims의 aud (문자열 또는 목록)
+func audienceClaim(claims map[string]interface{}) []string {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return []string{aud}
+	case []interface{}:
+		audience := make([]string, 0, len(aud))
+		for _, v := range aud {
+			if s, ok := v.(string); ok {
+				audience = append(audience, s)
+			}
+		}
+		return audience
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/signalable/qauth/internal/domain"
+)
+
+// DeadLetterStore 재시도를 모두 실패한 전송 보관소
+type DeadLetterStore interface {
+	Save(ctx context.Context, delivery *Delivery) error
+	Find(ctx context.Context, id string) (*Delivery, error)
+	List(ctx context.Context) ([]*Delivery, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type redisDeadLetterStore struct {
+	client *redis.Client
+	key    string
+}
+
+// NewRedisDeadLetterStore 전송 ID를 필드로 하는 Redis 해시 기반 보관소 생성자
+func NewRedisDeadLetterStore(client *redis.Client, key string) DeadLetterStore {
+	return &redisDeadLetterStore{
+		client: client,
+		key:    key,
+	}
+}
+
+func (s *redisDeadLetterStore) Save(ctx context.Context, delivery *Delivery) error {
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+	if err := s.client.HSet(ctx, s.key, delivery.ID, data).Err(); err != nil {
+		return fmt.Errorf("웹훅 실패 전송 저장 실패: %w", err)
+	}
+	return nil
+}
+
+func (s *redisDeadLetterStore) Find(ctx context.Context, id string) (*Delivery, error) {
+	data, err := s.client.HGet(ctx, s.key, id).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("웹훅 실패 전송 조회 실패: %w", err)
+	}
+
+	var delivery Delivery
+	if err := json.Unmarshal(data, &delivery); err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (s *redisDeadLetterStore) List(ctx context.Context) ([]*Delivery, error) {
+	values, err := s.client.HGetAll(ctx, s.key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("웹훅 실패 전송 목록 조회 실패: %w", err)
+	}
+
+	deliveries := make([]*Delivery, 0, len(values))
+	for _, data := range values {
+		var delivery Delivery
+		if err := json.Unmarshal([]byte(data), &delivery); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+
+	// 오래된 실패부터
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].FailedAt.Before(deliveries[j].FailedAt)
+	})
+	return deliveries, nil
+}
+
+func (s *redisDeadLetterStore) Delete(ctx context.Context, id string) error {
+	if err := s.client.HDel(ctx, s.key, id).Err(); err != nil {
+		return fmt.Errorf("웹훅 실패 전송 삭제 실패: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/signalable/qauth/internal/audit"
+)
+
+// Delivery 웹훅 전송 단위 (엔드포인트 하나에 이벤트 하나)
+type Delivery struct {
+	ID        string          `json:"id"`
+	Endpoint  string          `json:"endpoint"`
+	EventType audit.EventType `json:"event_type"`
+	Payload   json.RawMessage `json:"payload"`
+	Attempts  int             `json:"attempts"`
+	LastError string          `json:"last_error,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	FailedAt  time.Time       `json:"failed_at,omitempty"`
+}
@@ -0,0 +1,313 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	mrand "math/rand"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/signalable/qauth/internal/audit"
+)
+
+// ErrDeliveryFailed 재전송 실패 (실패 전송은 보관소에 그대로 남음)
+var ErrDeliveryFailed = errors.New("웹훅 전송 실패")
+
+// Options 전송 재시도 설정
+type Options struct {
+	MaxAttempts int
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	Timeout     time.Duration
+	Workers     int
+	QueueSize   int
+}
+
+// Dispatcher 감사 이벤트를 구독 중인 엔드포인트로 전송하는 audit.Sink
+//
+// 전송은 별도 worker에서 처리되고, 재시도를 모두 실패하면 DeadLetterStore에 보관됩니다.
+type Dispatcher struct {
+	endpoints  atomic.Pointer[map[string]Endpoint]
+	deadLetter DeadLetterStore
+	httpClient *http.Client
+	opts       Options
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan *Delivery
+	stop   context.Context
+	halt   context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDispatcher 웹훅 dispatcher 생성자 (worker는 즉시 시작됨)
+func NewDispatcher(endpoints []Endpoint, deadLetter DeadLetterStore, opts Options) *Dispatcher {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 1
+	}
+
+	stop, halt := context.WithCancel(context.Background())
+	d := &Dispatcher{
+		deadLetter: deadLetter,
+		httpClient: &http.Client{Timeout: opts.Timeout},
+		opts:       opts,
+		queue:      make(chan *Delivery, opts.QueueSize),
+		stop:       stop,
+		halt:       halt,
+	}
+	d.setEndpoints(endpoints)
+
+	for i := 0; i < opts.Workers; i++ {
+		d.wg.Add(1)
+		go d.work()
+	}
+	return d
+}
+
+// Prepare 새 엔드포인트 설정 파일을 검증 후 적용 함수 반환 (hot reload용, 빈 경로면 전송 중단)
+//
+// 대기 중인 전송은 적용 시점의 설정으로 보내고, 제거된 엔드포인트로의 전송은 보관소로 옮깁니다.
+func (d *Dispatcher) Prepare(path string) (func(), error) {
+	var endpoints []Endpoint
+	if path != "" {
+		loaded, err := LoadEndpoints(path)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = loaded
+	}
+	return func() { d.setEndpoints(endpoints) }, nil
+}
+
+func (d *Dispatcher) setEndpoints(endpoints []Endpoint) {
+	byName := make(map[string]Endpoint, len(endpoints))
+	for _, endpoint := range endpoints {
+		byName[endpoint.Name] = endpoint
+	}
+	d.endpoints.Store(&byName)
+}
+
+// endpoint 현재 설정의 엔드포인트
+func (d *Dispatcher) endpoint(name string) (Endpoint, bool) {
+	endpoint, ok := (*d.endpoints.Load())[name]
+	return endpoint, ok
+}
+
+// Write 이벤트를 구독 중인 엔드포인트별 전송으로 만들어 대기열에 추가
+//
+// 대기열이 가득 차면 요청을 막지 않고 바로 DeadLetterStore에 보관합니다.
+func (d *Dispatcher) Write(ctx context.Context, event audit.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	for _, endpoint := range *d.endpoints.Load() {
+		if !endpoint.Subscribes(event.Type) {
+			continue
+		}
+
+		delivery := &Delivery{
+			ID:        newDeliveryID(),
+			Endpoint:  endpoint.Name,
+			EventType: event.Type,
+			Payload:   payload,
+			CreatedAt: time.Now().UTC(),
+		}
+
+		if !d.enqueue(delivery) {
+			d.bury(ctx, delivery, errors.New("전송 대기열에 추가할 수 없습니다"))
+		}
+	}
+	return nil
+}
+
+// enqueue 대기열에 추가 (종료되었거나 가득 찼으면 false)
+func (d *Dispatcher) enqueue(delivery *Delivery) bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		return false
+	}
+	select {
+	case d.queue <- delivery:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close 대기 중인 전송을 마무리하고 worker 종료
+//
+// 종료 중 재시도 대기에 들어간 전송은 DeadLetterStore에 보관되어 나중에 재전송할 수 있습니다.
+func (d *Dispatcher) Close() error {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return nil
+	}
+	d.closed = true
+	d.halt()
+	close(d.queue)
+	d.mu.Unlock()
+
+	d.wg.Wait()
+	return nil
+}
+
+// DeadLetters 보관 중인 실패 전송 목록
+func (d *Dispatcher) DeadLetters(ctx context.Context) ([]*Delivery, error) {
+	return d.deadLetter.List(ctx)
+}
+
+// Replay 보관 중인 실패 전송을 한 번 재전송 (성공하면 보관소에서 삭제)
+func (d *Dispatcher) Replay(ctx context.Context, id string) (*Delivery, error) {
+	delivery, err := d.deadLetter.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, ok := d.endpoint(delivery.Endpoint)
+	if !ok {
+		return delivery, fmt.Errorf("%w: 설정에 없는 엔드포인트 %s", ErrDeliveryFailed, delivery.Endpoint)
+	}
+
+	delivery.Attempts++
+	if _, err := d.send(ctx, endpoint, delivery); err != nil {
+		delivery.LastError = err.Error()
+		delivery.FailedAt = time.Now().UTC()
+		if saveErr := d.deadLetter.Save(ctx, delivery); saveErr != nil {
+			return delivery, saveErr
+		}
+		return delivery, fmt.Errorf("%w: %v", ErrDeliveryFailed, err)
+	}
+
+	delivery.LastError = ""
+	return delivery, d.deadLetter.Delete(ctx, id)
+}
+
+func (d *Dispatcher) work() {
+	defer d.wg.Done()
+	for delivery := range d.queue {
+		d.deliver(delivery)
+	}
+}
+
+// deliver 지수 백오프로 재시도하며 전송
+func (d *Dispatcher) deliver(delivery *Delivery) {
+	endpoint, ok := d.endpoint(delivery.Endpoint)
+	if !ok {
+		d.bury(context.Background(), delivery, fmt.Errorf("설정에서 제거된 엔드포인트 %s", delivery.Endpoint))
+		return
+	}
+
+	var err error
+	for delivery.Attempts < d.opts.MaxAttempts {
+		if delivery.Attempts > 0 {
+			select {
+			case <-time.After(d.backoff(delivery.Attempts)):
+			case <-d.stop.Done():
+				d.bury(context.Background(), delivery, fmt.Errorf("종료로 재시도 중단: %w", err))
+				return
+			}
+		} else if d.stop.Err() != nil {
+			// 종료 중에는 남은 대기열을 전송하지 않고 보관만 함
+			d.bury(context.Background(), delivery, errors.New("종료로 전송 중단"))
+			return
+		}
+
+		delivery.Attempts++
+		var retryable bool
+		retryable, err = d.send(context.Background(), endpoint, delivery)
+		if err == nil {
+			return
+		}
+
+		slog.Warn("웹훅 전송 실패",
+			slog.String("endpoint", endpoint.Name),
+			slog.String("delivery_id", delivery.ID),
+			slog.Int("attempt", delivery.Attempts),
+			slog.Any("error", err),
+		)
+		if !retryable {
+			break
+		}
+	}
+
+	d.bury(context.Background(), delivery, err)
+}
+
+// send 서명된 요청 전송 (재시도할 만한 실패인지 함께 반환)
+func (d *Dispatcher) send(ctx context.Context, endpoint Endpoint, delivery *Delivery) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "qauth-webhook/1")
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderSignature, Sign(endpoint.Secret, time.Now(), delivery.Payload))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	err = fmt.Errorf("웹훅 응답 상태 %d", resp.StatusCode)
+
+	// 요청 자체가 거부된 4xx는 재시도해도 같은 결과
+	retryable := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout
+	return retryable, err
+}
+
+// backoff 재시도 대기 시간 (지수 증가 + 최대 50% jitter)
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	wait := float64(d.opts.BackoffBase) * math.Pow(2, float64(attempt-1))
+	if max := float64(d.opts.BackoffMax); max > 0 && wait > max {
+		wait = max
+	}
+	return time.Duration(wait/2 + mrand.Float64()*wait/2)
+}
+
+// bury 실패 전송을 DeadLetterStore에 보관
+func (d *Dispatcher) bury(ctx context.Context, delivery *Delivery, cause error) {
+	if cause != nil {
+		delivery.LastError = cause.Error()
+	}
+	delivery.FailedAt = time.Now().UTC()
+
+	if err := d.deadLetter.Save(ctx, delivery); err != nil {
+		slog.Error("웹훅 실패 전송 보관 실패",
+			slog.String("endpoint", delivery.Endpoint),
+			slog.String("delivery_id", delivery.ID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func newDeliveryID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/signalable/qauth/internal/audit"
+)
+
+// Endpoint 웹훅 수신 엔드포인트
+type Endpoint struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+
+	// Events 구독할 이벤트 종류 ("*" 또는 "token.*" 같은 접두사 패턴 허용)
+	Events []string `json:"events"`
+}
+
+// Subscribes 이벤트 구독 여부
+func (e Endpoint) Subscribes(eventType audit.EventType) bool {
+	for _, pattern := range e.Events {
+		if pattern == "*" || pattern == string(eventType) {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(string(eventType), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadEndpoints JSON 파일에서 엔드포인트 목록 로드
+func LoadEndpoints(path string) ([]Endpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("웹훅 설정 파일 읽기 실패: %w", err)
+	}
+
+	var endpoints []Endpoint
+	if err := json.Unmarshal(data, &endpoints); err != nil {
+		return nil, fmt.Errorf("웹훅 설정 파싱 실패: %w", err)
+	}
+
+	names := make(map[string]bool, len(endpoints))
+	for _, endpoint := range endpoints {
+		if endpoint.Name == "" || endpoint.URL == "" || endpoint.Secret == "" {
+			return nil, fmt.Errorf("웹훅 엔드포인트에는 name, url, secret이 필요합니다: %q", endpoint.Name)
+		}
+		if names[endpoint.Name] {
+			return nil, fmt.Errorf("중복된 웹훅 엔드포인트 이름: %s", endpoint.Name)
+		}
+		names[endpoint.Name] = true
+	}
+	return endpoints, nil
+}
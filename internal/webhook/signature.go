@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// 웹훅 요청 헤더
+const (
+	HeaderEvent     = "X-QAuth-Event"
+	HeaderDelivery  = "X-QAuth-Delivery"
+	HeaderSignature = "X-QAuth-Signature"
+)
+
+// Sign 서명 헤더 값 생성
+//
+// 형식은 "t=<unix 초>,v1=<hex>"이며, v1은 "<t>.<본문>"에 대한 HMAC-SHA256입니다.
+// 수신 측은 같은 방식으로 계산한 값과 비교하고 t로 재전송 여부를 판단합니다.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
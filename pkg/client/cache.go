@@ -0,0 +1,84 @@
+package client
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// validationCache 토큰 검증 결과 TTL 캐시
+type validationCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[[sha256.Size]byte]cacheEntry
+}
+
+type cacheEntry struct {
+	resp      TokenValidationResponse
+	expiresAt time.Time
+}
+
+// newValidationCache 검증 결과 캐시 생성자
+func newValidationCache(ttl time.Duration, maxEntries int) *validationCache {
+	return &validationCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[[sha256.Size]byte]cacheEntry),
+	}
+}
+
+// get 캐시된 검증 결과 조회 (토큰 원문 대신 해시를 키로 사용)
+func (c *validationCache) get(token string) (*TokenValidationResponse, bool) {
+	key := sha256.Sum256([]byte(token))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	resp := entry.resp
+	return &resp, true
+}
+
+// set 검증 결과 저장 (가득 차면 만료 항목 정리 후 임의 항목 제거)
+func (c *validationCache) set(token string, resp *TokenValidationResponse) {
+	key := sha256.Sum256([]byte(token))
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= c.maxEntries {
+		for k, e := range c.entries {
+			if now.After(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		for k := range c.entries {
+			if len(c.entries) < c.maxEntries {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+
+	c.entries[key] = cacheEntry{resp: *resp, expiresAt: now.Add(c.ttl)}
+}
+
+// delete 캐시 항목 제거
+func (c *validationCache) delete(token string) {
+	key := sha256.Sum256([]byte(token))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
@@ -0,0 +1,565 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/signalable/qauth/pkg/httpsig"
+)
+
+const (
+	defaultTimeout    = 5 * time.Second
+	defaultMaxRetries = 2
+	defaultBackoff    = 100 * time.Millisecond
+	maxBackoff        = 2 * time.Second
+)
+
+// 서버가 읽는 요청 헤더 (호출자 인증, DPoP 증명 대상 원 요청, 인증서 전달)
+const (
+	headerCaller              = "X-QAuth-Caller"
+	headerSignature           = "X-QAuth-Signature"
+	headerCallerKey           = "X-QAuth-Caller-Key"
+	headerDPoP                = "DPoP"
+	headerForwardedMethod     = "X-Forwarded-Method"
+	headerForwardedProto      = "X-Forwarded-Proto"
+	headerForwardedHost       = "X-Forwarded-Host"
+	headerForwardedURI        = "X-Forwarded-Uri"
+	headerForwardedClientCert = "X-Forwarded-Client-Cert"
+)
+
+// Client qauth HTTP API 클라이언트
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	timeout    time.Duration
+	maxRetries int
+	backoff    time.Duration
+	cache      *validationCache
+
+	// 내부 API 호출자 자격 증명
+	callerID     string
+	callerSecret string
+	callerKey    string
+	signer       *httpsig.Signer
+}
+
+// Option 클라이언트 설정 옵션
+type Option func(*Client)
+
+// WithHTTPClient 사용할 http.Client 지정
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTimeout 호출당 제한 시간 지정 (context에 더 짧은 deadline이 있으면 그 값을 따름)
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithRetry 최대 재시도 횟수와 초기 백오프 지정
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// WithValidationCache 토큰 검증 결과 캐시 사용 (기본값은 캐시하지 않음, ttl이 0이면 비활성화)
+//
+// 캐시된 동안에는 서버에서 폐기된 토큰도 유효하게 보이므로 ttl은 허용할 수 있는 폐기 반영 지연보다 짧게 잡으세요.
+func WithValidationCache(ttl time.Duration, maxEntries int) Option {
+	return func(c *Client) {
+		if ttl <= 0 || maxEntries <= 0 {
+			c.cache = nil
+			return
+		}
+		c.cache = newValidationCache(ttl, maxEntries)
+	}
+}
+
+// WithCallerSignature 내부 API 요청에 호출자 ID와 공유 시크릿 HMAC 서명 추가
+func WithCallerSignature(callerID, secret string) Option {
+	return func(c *Client) {
+		c.callerID = callerID
+		c.callerSecret = secret
+	}
+}
+
+// WithCallerKey 내부 API 요청에 호출자 API 키 추가
+func WithCallerKey(apiKey string) Option {
+	return func(c *Client) {
+		c.callerKey = apiKey
+	}
+}
+
+// WithMessageSignature 요청마다 HTTP 메시지 서명(RFC 9421) 추가 (KeyID는 등록된 호출자 ID)
+func WithMessageSignature(signer *httpsig.Signer) Option {
+	return func(c *Client) {
+		c.signer = signer
+	}
+}
+
+// New qauth 클라이언트 생성자
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{},
+		timeout:    defaultTimeout,
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// CreateToken 사용자 토큰 발급 (내부 서비스 전용 API)
+//
+// 응답을 받지 못한 요청도 서버에서는 세션이 만들어졌을 수 있으므로 재시도하지 않습니다.
+func (c *Client) CreateToken(ctx context.Context, userID string) (*AuthResponse, error) {
+	req := request{
+		method: http.MethodPost,
+		path:   "/api/auth/token",
+		header: http.Header{"X-User-ID": {userID}},
+	}
+
+	var resp AuthResponse
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ValidateToken 토큰 검증 (유효한 결과는 캐시에 보관)
+func (c *Client) ValidateToken(ctx context.Context, token string) (*TokenValidationResponse, error) {
+	if c.cache != nil {
+		if resp, ok := c.cache.get(token); ok {
+			return resp, nil
+		}
+	}
+
+	req := request{
+		method:     http.MethodGet,
+		path:       "/api/auth/token/validate",
+		header:     bearer(token),
+		idempotent: true,
+	}
+
+	var resp TokenValidationResponse
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+
+	if c.cache != nil && resp.Valid {
+		c.cache.set(token, &resp)
+	}
+	return &resp, nil
+}
+
+// TokenStatus 토큰의 서명, 만료, 폐기 여부만 조회 (키 바인딩의 소유 증명은 확인하지 않음)
+//
+// 바인딩을 직접 확인하는 리소스 서버의 폐기 확인용이며, 폐기 여부가 바로 반영되도록 캐시하지 않습니다.
+func (c *Client) TokenStatus(ctx context.Context, token string) (*TokenStatusResponse, error) {
+	req := request{
+		method:     http.MethodGet,
+		path:       "/api/auth/token/status",
+		header:     bearer(token),
+		idempotent: true,
+	}
+
+	var resp TokenStatusResponse
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CreateDPoPToken 클라이언트의 DPoP 증명 키에 바인딩된 토큰 발급 (내부 서비스 전용 API)
+//
+// proof에는 클라이언트가 보낸 DPoP 헤더 값과 클라이언트가 호출한 로그인 요청의 메서드/주소를 지정합니다.
+// 증명은 한 번만 쓸 수 있으므로 재시도하지 않습니다.
+func (c *Client) CreateDPoPToken(ctx context.Context, userID string, proof ProofRequest) (*AuthResponse, error) {
+	header, err := proofHeader(proof)
+	if err != nil {
+		return nil, err
+	}
+	header.Set("X-User-ID", userID)
+
+	req := request{
+		method: http.MethodPost,
+		path:   "/api/auth/token",
+		header: header,
+	}
+
+	var resp AuthResponse
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ValidateDPoPToken DPoP 바인딩 토큰 검증
+//
+// proof에는 리소스 서버가 받은 DPoP 헤더 값과 요청 메서드/주소를 지정합니다.
+// 증명은 한 번만 쓸 수 있으므로 캐시와 재시도 없이 매번 검증합니다.
+func (c *Client) ValidateDPoPToken(ctx context.Context, token string, proof ProofRequest) (*TokenValidationResponse, error) {
+	header, err := proofHeader(proof)
+	if err != nil {
+		return nil, err
+	}
+	header.Set("Authorization", "DPoP "+token)
+
+	req := request{
+		method: http.MethodGet,
+		path:   "/api/auth/token/validate",
+		header: header,
+	}
+
+	var resp TokenValidationResponse
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ValidateCertificateBoundToken 인증서 바인딩 토큰 검증
+//
+// cert에는 리소스 서버가 mTLS 연결에서 받은 클라이언트 인증서를 지정하며, qauth의 인증서 전달 헤더가
+// 기본값(X-Forwarded-Client-Cert)이어야 합니다. 검증 결과가 연결마다 다르므로 캐시하지 않습니다.
+func (c *Client) ValidateCertificateBoundToken(ctx context.Context, token string, cert *x509.Certificate) (*TokenValidationResponse, error) {
+	header := bearer(token)
+	if cert != nil {
+		header.Set(headerForwardedClientCert, url.PathEscape(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))))
+	}
+
+	req := request{
+		method:     http.MethodGet,
+		path:       "/api/auth/token/validate",
+		header:     header,
+		idempotent: true,
+	}
+
+	var resp TokenValidationResponse
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ExchangeToken 사용자 토큰을 대상 서비스 전용 위임 토큰으로 교환 (RFC 8693, 내부 서비스 전용 API)
+//
+// actor_token을 지정하지 않으면 이 클라이언트의 호출자 ID가 act claim에 기록됩니다.
+// 교환할 때마다 새 토큰을 발급하므로 재시도하지 않습니다.
+func (c *Client) ExchangeToken(ctx context.Context, exchange TokenExchangeRequest) (*TokenExchangeResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", GrantTypeTokenExchange)
+	form.Set("subject_token", exchange.SubjectToken)
+	form.Set("subject_token_type", defaultTokenType(exchange.SubjectTokenType))
+	if exchange.ActorToken != "" {
+		form.Set("actor_token", exchange.ActorToken)
+		form.Set("actor_token_type", defaultTokenType(exchange.ActorTokenType))
+	}
+	form.Set("audience", exchange.Audience)
+	if len(exchange.Scopes) > 0 {
+		form.Set("scope", strings.Join(exchange.Scopes, " "))
+	}
+	if exchange.RequestedTokenType != "" {
+		form.Set("requested_token_type", exchange.RequestedTokenType)
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req := request{
+		method: http.MethodPost,
+		path:   "/api/auth/token",
+		header: header,
+		body:   []byte(form.Encode()),
+	}
+
+	var resp TokenExchangeResponse
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// defaultTokenType 토큰 종류 식별자 (비어 있으면 access_token)
+func defaultTokenType(tokenType string) string {
+	if tokenType == "" {
+		return TokenTypeAccessToken
+	}
+	return tokenType
+}
+
+// RefreshToken 토큰 새로고침 (이전 토큰이 폐기되므로 재시도하지 않음)
+func (c *Client) RefreshToken(ctx context.Context, token string) (*AuthResponse, error) {
+	if c.cache != nil {
+		c.cache.delete(token)
+	}
+
+	req := request{
+		method: http.MethodPost,
+		path:   "/api/auth/token/refresh",
+		header: bearer(token),
+	}
+
+	var resp AuthResponse
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CheckLogin 로그인 시도 허용 여부 확인 (자격 증명을 확인하기 전에 호출, 내부 서비스 전용 API)
+//
+// false면 계정이나 IP가 잠겼거나 대기 시간 중이므로 자격 증명과 무관하게 일반 로그인 실패로 응답합니다.
+func (c *Client) CheckLogin(ctx context.Context, account, ip string) (bool, error) {
+	req, err := loginRequest("/api/auth/login/check", account, ip)
+	if err != nil {
+		return false, err
+	}
+	req.idempotent = true
+
+	var resp loginCheckResponse
+	if err := c.do(ctx, req, &resp); err != nil {
+		return false, err
+	}
+	return resp.Allowed, nil
+}
+
+// ReportLoginFailure 로그인 실패 보고 (실패 횟수가 중복 집계되지 않도록 재시도하지 않음)
+func (c *Client) ReportLoginFailure(ctx context.Context, account, ip string) error {
+	req, err := loginRequest("/api/auth/login/failure", account, ip)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, req, nil)
+}
+
+// ReportLoginSuccess 로그인 성공 보고 (계정의 실패 기록 초기화)
+func (c *Client) ReportLoginSuccess(ctx context.Context, account, ip string) error {
+	req, err := loginRequest("/api/auth/login/success", account, ip)
+	if err != nil {
+		return err
+	}
+	req.idempotent = true
+	return c.do(ctx, req, nil)
+}
+
+// loginRequest 로그인 시도 보고 요청 생성
+func loginRequest(path, account, ip string) (request, error) {
+	body, err := json.Marshal(loginAttempt{Account: account, IP: ip})
+	if err != nil {
+		return request{}, err
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	return request{
+		method: http.MethodPost,
+		path:   path,
+		header: header,
+		body:   body,
+	}, nil
+}
+
+// RevokeToken 토큰 폐기
+func (c *Client) RevokeToken(ctx context.Context, token string) error {
+	if c.cache != nil {
+		c.cache.delete(token)
+	}
+
+	req := request{
+		method:     http.MethodPost,
+		path:       "/api/auth/token/revoke",
+		header:     bearer(token),
+		idempotent: true,
+	}
+	return c.do(ctx, req, nil)
+}
+
+// request 단일 API 호출 정의
+type request struct {
+	method     string
+	path       string
+	header     http.Header
+	body       []byte
+	idempotent bool
+}
+
+// do 재시도와 백오프를 적용해 요청 실행
+func (c *Client) do(ctx context.Context, req request, out interface{}) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	attempts := 1
+	if req.idempotent {
+		attempts += c.maxRetries
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if err := c.sleep(ctx, attempt); err != nil {
+				return lastErr
+			}
+		}
+
+		retry, err := c.send(ctx, req, out)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+	return lastErr
+}
+
+// send 요청을 한 번 전송하고 재시도 가능 여부 반환
+func (c *Client) send(ctx context.Context, req request, out interface{}) (bool, error) {
+	var body io.Reader
+	if req.body != nil {
+		body = bytes.NewReader(req.body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, c.baseURL+req.path, body)
+	if err != nil {
+		return false, fmt.Errorf("요청 생성 실패: %w", err)
+	}
+	for k, v := range req.header {
+		httpReq.Header[http.CanonicalHeaderKey(k)] = v
+	}
+	if err := c.authenticate(httpReq, req.body); err != nil {
+		return false, err
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		// context 취소/만료는 재시도하지 않음
+		return ctx.Err() == nil, fmt.Errorf("요청 전송 실패: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return true, fmt.Errorf("응답 읽기 실패: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		apiErr := newError(resp.StatusCode, data)
+		retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+		return retry, apiErr
+	}
+
+	if out == nil || len(data) == 0 {
+		return false, nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return false, fmt.Errorf("응답 역직렬화 실패: %w", err)
+	}
+	return false, nil
+}
+
+// authenticate 호출자 자격 증명 헤더 추가 (재시도마다 새 시각과 nonce로 서명)
+func (c *Client) authenticate(httpReq *http.Request, body []byte) error {
+	if c.callerKey != "" {
+		httpReq.Header.Set(headerCallerKey, c.callerKey)
+	}
+	if c.callerSecret != "" {
+		httpReq.Header.Set(headerCaller, c.callerID)
+		signature, err := signRequest(c.callerSecret, time.Now(), httpReq.Method, httpReq.URL.RequestURI(), body)
+		if err != nil {
+			return err
+		}
+		httpReq.Header.Set(headerSignature, signature)
+	}
+	if c.signer != nil {
+		if err := c.signer.Sign(httpReq, body); err != nil {
+			return fmt.Errorf("요청 서명 실패: %w", err)
+		}
+	}
+	return nil
+}
+
+// signRequest HMAC 호출자 서명 헤더 값 "t=<unix 초>,n=<nonce>,v1=<hex>"
+//
+// v1은 "<t>.<nonce>.<메서드>.<경로와 쿼리>.<본문>"에 대한 HMAC-SHA256이며,
+// 서버가 같은 nonce의 재사용을 거부하므로 요청(재시도 포함)마다 새 nonce를 만듭니다.
+func signRequest(secret string, timestamp time.Time, method, uri string, body []byte) (string, error) {
+	b := make([]byte, 16)
+	if _, err := crand.Read(b); err != nil {
+		return "", fmt.Errorf("서명 nonce 생성 실패: %w", err)
+	}
+	nonce := hex.EncodeToString(b)
+
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t + "." + nonce + "." + strings.ToUpper(method) + "." + uri + "."))
+	mac.Write(body)
+	return "t=" + t + ",n=" + nonce + ",v1=" + hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// sleep 지수 백오프 + 지터만큼 대기
+func (c *Client) sleep(ctx context.Context, attempt int) error {
+	delay := c.backoff << (attempt - 1)
+	if delay > maxBackoff || delay <= 0 {
+		delay = maxBackoff
+	}
+	delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// proofHeader DPoP 증명과 증명 대상 원 요청 헤더 생성
+func proofHeader(proof ProofRequest) (http.Header, error) {
+	u, err := url.Parse(proof.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("DPoP 증명 대상 주소가 올바르지 않습니다: %q", proof.URL)
+	}
+
+	header := http.Header{}
+	header.Set(headerDPoP, proof.Proof)
+	header.Set(headerForwardedMethod, proof.Method)
+	header.Set(headerForwardedProto, u.Scheme)
+	header.Set(headerForwardedHost, u.Host)
+	header.Set(headerForwardedURI, u.RequestURI())
+	return header, nil
+}
+
+// bearer Authorization 헤더 생성
+func bearer(token string) http.Header {
+	return http.Header{"Authorization": {"Bearer " + token}}
+}
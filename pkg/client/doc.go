@@ -0,0 +1,14 @@
+// Package client qauth 인증 서비스용 Go 클라이언트
+//
+// 다운스트림 서비스에서 토큰 발급/검증/새로고침/폐기 API를 호출할 때 사용합니다.
+// 재시도(지수 백오프), 호출 제한 시간, 검증 결과 캐시를 제공하며
+// 에러는 errors.Is로 이 패키지의 Err* 값과 비교할 수 있습니다. 검증 결과 캐시는 WithValidationCache로 켭니다.
+// 내부 API(토큰 발급/검증)를 호출하는 서비스는 WithMessageSignature, WithCallerSignature,
+// WithCallerKey 중 하나로 등록된 호출자 자격 증명을 지정합니다.
+//
+//	c := client.New("http://qauth:8080")
+//	resp, err := c.ValidateToken(ctx, token)
+//	if errors.Is(err, client.ErrExpiredToken) {
+//		// 토큰 새로고침
+//	}
+package client
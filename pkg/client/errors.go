@@ -0,0 +1,116 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// 서버 에러 코드에 대응하는 에러 (errors.Is로 *Error와 비교)
+var (
+	ErrInvalidRequest       = errors.New("qauth: invalid request")
+	ErrMissingToken         = errors.New("qauth: missing token")
+	ErrInvalidToken         = errors.New("qauth: invalid token")
+	ErrExpiredToken         = errors.New("qauth: expired token")
+	ErrRevokedToken         = errors.New("qauth: revoked token")
+	ErrInvalidDPoPProof     = errors.New("qauth: invalid DPoP proof")
+	ErrAuthenticationFailed = errors.New("qauth: caller authentication failed")
+	ErrInvalidCredentials   = errors.New("qauth: invalid credentials")
+	ErrForbidden            = errors.New("qauth: forbidden")
+	ErrNotFound             = errors.New("qauth: not found")
+	ErrOriginNotAllowed     = errors.New("qauth: origin not allowed")
+	ErrRateLimited          = errors.New("qauth: rate limited")
+	ErrBodyTooLarge         = errors.New("qauth: request body too large")
+	ErrInvalidScope         = errors.New("qauth: invalid scope")
+	ErrInvalidTarget        = errors.New("qauth: invalid target")
+)
+
+// Error qauth API 에러 응답
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestID  string
+
+	// err 응답에 대응하는 sentinel 에러 (없으면 nil)
+	err error
+}
+
+func (e *Error) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("qauth: %d %s: %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("qauth: %d %s", e.StatusCode, e.Message)
+}
+
+// Unwrap errors.Is(err, client.ErrInvalidToken) 등의 비교 지원
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// codeErrors 서버 에러 코드별 sentinel 에러
+var codeErrors = map[string]error{
+	"invalid_request":       ErrInvalidRequest,
+	"missing_token":         ErrMissingToken,
+	"invalid_token":         ErrInvalidToken,
+	"expired_token":         ErrExpiredToken,
+	"revoked_token":         ErrRevokedToken,
+	"invalid_dpop_proof":    ErrInvalidDPoPProof,
+	"authentication_failed": ErrAuthenticationFailed,
+	"invalid_credentials":   ErrInvalidCredentials,
+	"forbidden":             ErrForbidden,
+	"not_found":             ErrNotFound,
+	"origin_not_allowed":    ErrOriginNotAllowed,
+	"rate_limited":          ErrRateLimited,
+	"body_too_large":        ErrBodyTooLarge,
+	"invalid_scope":         ErrInvalidScope,
+	"invalid_target":        ErrInvalidTarget,
+}
+
+// newError 상태 코드와 응답 본문으로 에러 생성
+func newError(statusCode int, body []byte) *Error {
+	var envelope struct {
+		Code      string `json:"code"`
+		Message   string `json:"message"`
+		RequestID string `json:"request_id"`
+	}
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope.Code != "" {
+		return &Error{
+			StatusCode: statusCode,
+			Code:       envelope.Code,
+			Message:    envelope.Message,
+			RequestID:  envelope.RequestID,
+			err:        codeErrors[envelope.Code],
+		}
+	}
+
+	// JSON이 아닌 응답 (프록시 에러 페이지 등)
+	message := strings.TrimSpace(string(body))
+	if message == "" {
+		message = http.StatusText(statusCode)
+	}
+
+	return &Error{
+		StatusCode: statusCode,
+		Message:    message,
+		err:        statusError(statusCode),
+	}
+}
+
+// statusError 에러 코드가 없는 응답을 상태 코드로 sentinel 에러에 매핑
+func statusError(statusCode int) error {
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return ErrInvalidToken
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusRequestEntityTooLarge:
+		return ErrBodyTooLarge
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,109 @@
+package client
+
+// 토큰 교환 grant와 토큰 종류 식별자 (RFC 8693 3)
+const (
+	GrantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	TokenTypeAccessToken   = "urn:ietf:params:oauth:token-type:access_token"
+	TokenTypeJWT           = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+// AuthResponse 토큰 발급/새로고침 응답
+type AuthResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// TokenValidationResponse 토큰 검증 응답
+type TokenValidationResponse struct {
+	Valid  bool   `json:"valid"`
+	UserID string `json:"user_id,omitempty"`
+
+	// Confirmation 소유 증명이 확인된 키 (키에 바인딩된 토큰만)
+	Confirmation *Confirmation `json:"cnf,omitempty"`
+
+	// Scopes 자격 증명에 허용된 권한 범위 (API 키와 교환 토큰)
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Audience 토큰 사용 대상 (aud claim)
+	Audience []string `json:"aud,omitempty"`
+
+	// Actor 위임 토큰이면 사용자를 대신해 호출하는 주체
+	Actor *Actor `json:"act,omitempty"`
+
+	// Impersonation 관리자 대리 로그인 토큰 여부 (Actor가 관리자)
+	Impersonation bool `json:"impersonation,omitempty"`
+
+	// APIKeyID 검증한 자격 증명이 API 키면 키 ID
+	APIKeyID string `json:"api_key_id,omitempty"`
+}
+
+// TokenStatusResponse 토큰 상태 조회 응답 (키 바인딩의 소유 증명 없이 폐기 여부만 확인)
+type TokenStatusResponse struct {
+	Active    bool   `json:"active"`
+	UserID    string `json:"user_id,omitempty"`
+	ExpiresAt int64  `json:"expires_at,omitempty"`
+}
+
+// Confirmation 토큰이 바인딩된 키 (RFC 7800 cnf claim)
+type Confirmation struct {
+	// JKT DPoP 공개키의 JWK SHA-256 thumbprint (RFC 9449)
+	JKT string `json:"jkt,omitempty"`
+
+	// X5T 클라이언트 인증서의 SHA-256 thumbprint (RFC 8705)
+	X5T string `json:"x5t#S256,omitempty"`
+}
+
+// Actor 위임 토큰에서 사용자를 대신해 호출하는 주체 (RFC 8693 4.1 act claim, 이전 actor는 중첩)
+type Actor struct {
+	Subject string `json:"sub"`
+	Actor   *Actor `json:"act,omitempty"`
+}
+
+// ProofRequest DPoP 증명과 증명 대상 요청 (RFC 9449)
+type ProofRequest struct {
+	// Proof DPoP 헤더 값 (증명 JWT)
+	Proof string
+
+	// Method, URL 증명의 htm/htu와 비교할 요청 메서드와 주소
+	Method string
+	URL    string
+}
+
+// TokenExchangeRequest 토큰 교환 요청 (RFC 8693 2.1)
+type TokenExchangeRequest struct {
+	SubjectToken     string
+	SubjectTokenType string
+
+	// ActorToken 사용자를 대신해 호출하는 서비스의 토큰 (없으면 이 클라이언트의 호출자가 actor)
+	ActorToken     string
+	ActorTokenType string
+
+	// Audience 교환 토큰을 사용할 대상 서비스
+	Audience string
+
+	// Scopes 요청 scope (비어 있으면 허용 범위 전체)
+	Scopes []string
+
+	RequestedTokenType string
+}
+
+// TokenExchangeResponse 토큰 교환 응답 (RFC 8693 2.2.1)
+type TokenExchangeResponse struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int64  `json:"expires_in"`
+	Scope           string `json:"scope,omitempty"`
+}
+
+// loginAttempt 로그인 시도 보고 요청 본문
+type loginAttempt struct {
+	Account string `json:"account"`
+	IP      string `json:"ip,omitempty"`
+}
+
+// loginCheckResponse 로그인 시도 허용 여부 응답
+type loginCheckResponse struct {
+	Allowed bool `json:"allowed"`
+}
@@ -0,0 +1,34 @@
+package httpsig
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// HeaderContentDigest 본문 다이제스트 헤더 (RFC 9530)
+const HeaderContentDigest = "Content-Digest"
+
+// ContentDigest 본문의 Content-Digest 헤더 값 ("sha-256=:<base64>:")
+func ContentDigest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "sha-256=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":"
+}
+
+// VerifyContentDigest Content-Digest 헤더가 본문과 일치하는지 확인 (sha-256만 지원)
+func VerifyContentDigest(header string, body []byte) error {
+	expected := ContentDigest(body)
+	for _, member := range strings.Split(header, ",") {
+		member = strings.TrimSpace(member)
+		if !strings.HasPrefix(member, "sha-256=") {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(member), []byte(expected)) == 1 {
+			return nil
+		}
+		return errors.New("httpsig: content digest mismatch")
+	}
+	return errors.New("httpsig: missing sha-256 content digest")
+}
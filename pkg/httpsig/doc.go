@@ -0,0 +1,14 @@
+// Package httpsig HTTP 메시지 서명(RFC 9421)과 Content-Digest(RFC 9530) 구현
+//
+// qauth 내부 API를 호출하는 서비스가 요청 무결성과 재전송 방지를 위해 사용합니다.
+// 서명은 @method, @path, date, content-digest와 선택한 헤더를 대상으로 하며,
+// 서버는 keyid로 호출자 키를 찾고 created/nonce로 재전송을 거부합니다.
+//
+//	signer := &httpsig.Signer{
+//		KeyID:     "user-service",
+//		Algorithm: httpsig.AlgHMACSHA256,
+//		Key:       []byte(secret),
+//		Headers:   []string{"x-user-id"},
+//	}
+//	httpClient := &http.Client{Transport: signer.Transport(nil)}
+package httpsig
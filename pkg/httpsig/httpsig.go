@@ -0,0 +1,397 @@
+package httpsig
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 서명 헤더
+const (
+	HeaderSignatureInput = "Signature-Input"
+	HeaderSignature      = "Signature"
+)
+
+// 지원 서명 알고리즘 (RFC 9421 3.3)
+const (
+	AlgHMACSHA256 = "hmac-sha256"
+	AlgEd25519    = "ed25519"
+)
+
+// DefaultComponents 항상 서명 대상에 포함하는 컴포넌트
+var DefaultComponents = []string{"@method", "@path", "date", "content-digest"}
+
+// signatureLabel Signer가 사용하는 서명 레이블
+const signatureLabel = "sig1"
+
+// Signer 요청 서명기
+type Signer struct {
+	KeyID     string
+	Algorithm string
+
+	// Key hmac-sha256은 []byte 공유 시크릿, ed25519는 ed25519.PrivateKey
+	Key interface{}
+
+	// Headers DefaultComponents 외에 서명할 헤더 (소문자, 요청에 있는 헤더만 포함)
+	Headers []string
+
+	// Now 현재 시각 (테스트용, 비우면 time.Now)
+	Now func() time.Time
+}
+
+// Sign 요청에 Date, Content-Digest, Signature-Input, Signature 헤더 추가
+//
+// body는 요청 본문 전체여야 하며 본문이 없으면 nil을 전달합니다.
+func (s *Signer) Sign(req *http.Request, body []byte) error {
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	created := now()
+
+	if req.Header.Get("Date") == "" {
+		req.Header.Set("Date", created.UTC().Format(http.TimeFormat))
+	}
+	req.Header.Set(HeaderContentDigest, ContentDigest(body))
+
+	components := append([]string(nil), DefaultComponents...)
+	for _, header := range s.Headers {
+		header = strings.ToLower(header)
+		if req.Header.Get(header) != "" && !contains(components, header) {
+			components = append(components, header)
+		}
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("httpsig: failed to generate nonce: %w", err)
+	}
+
+	quoted := make([]string, len(components))
+	for i, c := range components {
+		quoted[i] = quote(c)
+	}
+	params := fmt.Sprintf("(%s);created=%d;keyid=%s;alg=%s;nonce=%s",
+		strings.Join(quoted, " "),
+		created.Unix(),
+		quote(s.KeyID),
+		quote(s.Algorithm),
+		quote(base64.RawURLEncoding.EncodeToString(nonce)),
+	)
+
+	base, err := signatureBase(req, components, params)
+	if err != nil {
+		return err
+	}
+	sig, err := sign(s.Algorithm, s.Key, base)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set(HeaderSignatureInput, signatureLabel+"="+params)
+	req.Header.Set(HeaderSignature, signatureLabel+"=:"+base64.StdEncoding.EncodeToString(sig)+":")
+	return nil
+}
+
+// Transport 요청마다 서명을 추가하는 http.RoundTripper (base가 nil이면 http.DefaultTransport)
+func (s *Signer) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{signer: s, base: base}
+}
+
+type transport struct {
+	signer *Signer
+	base   http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("httpsig: failed to read request body: %w", err)
+		}
+	}
+
+	// RoundTripper는 원래 요청을 수정하면 안 되므로 복사본에 서명
+	signed := req.Clone(req.Context())
+	signed.Body = io.NopCloser(bytes.NewReader(body))
+	signed.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	if err := t.signer.Sign(signed, body); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(signed)
+}
+
+// Params 검증된 서명의 파라미터
+type Params struct {
+	KeyID      string
+	Algorithm  string
+	Nonce      string
+	Created    time.Time
+	Components []string
+}
+
+// KeyFunc keyid로 검증 키 조회 (hmac-sha256은 []byte, ed25519는 ed25519.PublicKey)
+type KeyFunc func(keyID string) (interface{}, error)
+
+// VerifyOptions 서명 검증 옵션
+type VerifyOptions struct {
+	Key KeyFunc
+
+	// Required 반드시 서명에 포함되어야 하는 컴포넌트 (비우면 DefaultComponents)
+	Required []string
+
+	// RequiredIfPresent 요청에 있으면 서명에 포함되어야 하는 헤더 (소문자)
+	RequiredIfPresent []string
+
+	// MaxSkew created와 Date 헤더의 허용 오차
+	MaxSkew time.Duration
+
+	// Now 현재 시각 (테스트용, 비우면 time.Now)
+	Now func() time.Time
+}
+
+// Verify 요청의 서명을 검증하고 서명 파라미터 반환
+//
+// 서명이 여러 개면 레이블 순으로 처음 것을 검증합니다. nonce 중복 확인은 호출 측의 몫입니다.
+func Verify(req *http.Request, body []byte, opts VerifyOptions) (*Params, error) {
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+
+	inputs, err := parseSignatureInputs(strings.Join(req.Header.Values(HeaderSignatureInput), ", "))
+	if err != nil {
+		return nil, err
+	}
+	signatures, err := parseSignatures(strings.Join(req.Header.Values(HeaderSignature), ", "))
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]string, 0, len(inputs))
+	for label := range inputs {
+		if _, ok := signatures[label]; ok {
+			labels = append(labels, label)
+		}
+	}
+	if len(labels) == 0 {
+		return nil, errors.New("httpsig: no signature")
+	}
+	sort.Strings(labels)
+	input := inputs[labels[0]]
+
+	required := opts.Required
+	if len(required) == 0 {
+		required = DefaultComponents
+	}
+	for _, c := range required {
+		if !contains(input.components, c) {
+			return nil, fmt.Errorf("httpsig: component %q is not covered", c)
+		}
+	}
+	for _, header := range opts.RequiredIfPresent {
+		if req.Header.Get(header) != "" && !contains(input.components, strings.ToLower(header)) {
+			return nil, fmt.Errorf("httpsig: header %q is not covered", header)
+		}
+	}
+
+	params := &Params{Components: input.components}
+	params.KeyID, _ = input.param("keyid")
+	params.Algorithm, _ = input.param("alg")
+	params.Nonce, _ = input.param("nonce")
+	if params.KeyID == "" {
+		return nil, errors.New("httpsig: missing keyid")
+	}
+
+	created, ok := input.intParam("created")
+	if !ok {
+		return nil, errors.New("httpsig: missing created")
+	}
+	params.Created = time.Unix(created, 0)
+	if skew := now().Sub(params.Created); skew > opts.MaxSkew || skew < -opts.MaxSkew {
+		return nil, errors.New("httpsig: signature created outside the allowed window")
+	}
+	if expires, ok := input.intParam("expires"); ok && now().Unix() > expires {
+		return nil, errors.New("httpsig: signature expired")
+	}
+	if contains(input.components, "date") {
+		date, err := http.ParseTime(req.Header.Get("Date"))
+		if err != nil {
+			return nil, errors.New("httpsig: invalid date header")
+		}
+		if skew := now().Sub(date); skew > opts.MaxSkew || skew < -opts.MaxSkew {
+			return nil, errors.New("httpsig: date outside the allowed window")
+		}
+	}
+
+	key, err := opts.Key(params.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	alg, err := algorithmFor(key, params.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	params.Algorithm = alg
+
+	sig, err := base64.StdEncoding.DecodeString(signatures[labels[0]])
+	if err != nil {
+		return nil, errors.New("httpsig: malformed signature")
+	}
+	base, err := signatureBase(req, input.components, input.raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := verify(alg, key, base, sig); err != nil {
+		return nil, err
+	}
+
+	if contains(input.components, "content-digest") {
+		if err := VerifyContentDigest(req.Header.Get(HeaderContentDigest), body); err != nil {
+			return nil, err
+		}
+	}
+	return params, nil
+}
+
+// signatureBase 서명 대상 문자열 생성 (RFC 9421 2.5)
+func signatureBase(req *http.Request, components []string, params string) ([]byte, error) {
+	var b strings.Builder
+	for _, c := range components {
+		value, err := componentValue(req, c)
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString(quote(c))
+		b.WriteString(": ")
+		b.WriteString(value)
+		b.WriteString("\n")
+	}
+	b.WriteString(`"@signature-params": `)
+	b.WriteString(params)
+	return []byte(b.String()), nil
+}
+
+func componentValue(req *http.Request, component string) (string, error) {
+	switch component {
+	case "@method":
+		return strings.ToUpper(req.Method), nil
+	case "@path":
+		if path := req.URL.EscapedPath(); path != "" {
+			return path, nil
+		}
+		return "/", nil
+	case "@query":
+		return "?" + req.URL.RawQuery, nil
+	case "@authority":
+		host := req.Host
+		if host == "" {
+			host = req.URL.Host
+		}
+		return strings.ToLower(host), nil
+	}
+	if strings.HasPrefix(component, "@") {
+		return "", fmt.Errorf("httpsig: unsupported component %q", component)
+	}
+
+	// Header.Values는 요청 헤더의 슬라이스를 그대로 반환하므로 복사본에서 공백 제거
+	raw := req.Header.Values(component)
+	if len(raw) == 0 {
+		return "", fmt.Errorf("httpsig: covered header %q is missing", component)
+	}
+	values := make([]string, len(raw))
+	for i, v := range raw {
+		values[i] = strings.TrimSpace(v)
+	}
+	return strings.Join(values, ", "), nil
+}
+
+func sign(alg string, key interface{}, base []byte) ([]byte, error) {
+	switch alg {
+	case AlgHMACSHA256:
+		secret, ok := key.([]byte)
+		if !ok {
+			return nil, errors.New("httpsig: hmac-sha256 requires a []byte key")
+		}
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(base)
+		return mac.Sum(nil), nil
+	case AlgEd25519:
+		priv, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, errors.New("httpsig: ed25519 requires an ed25519.PrivateKey")
+		}
+		return ed25519.Sign(priv, base), nil
+	default:
+		return nil, fmt.Errorf("httpsig: unsupported algorithm %q", alg)
+	}
+}
+
+func verify(alg string, key interface{}, base, sig []byte) error {
+	switch alg {
+	case AlgHMACSHA256:
+		mac := hmac.New(sha256.New, key.([]byte))
+		mac.Write(base)
+		if !hmac.Equal(mac.Sum(nil), sig) {
+			return errors.New("httpsig: signature mismatch")
+		}
+	case AlgEd25519:
+		if !ed25519.Verify(key.(ed25519.PublicKey), base, sig) {
+			return errors.New("httpsig: signature mismatch")
+		}
+	default:
+		return fmt.Errorf("httpsig: unsupported algorithm %q", alg)
+	}
+	return nil
+}
+
+// algorithmFor 키 종류에 맞는 알고리즘 (alg 파라미터가 있으면 키와 일치해야 함)
+func algorithmFor(key interface{}, alg string) (string, error) {
+	var keyAlg string
+	switch key.(type) {
+	case []byte:
+		keyAlg = AlgHMACSHA256
+	case ed25519.PublicKey:
+		keyAlg = AlgEd25519
+	default:
+		return "", errors.New("httpsig: unsupported key type")
+	}
+	if alg != "" && alg != keyAlg {
+		return "", fmt.Errorf("httpsig: algorithm %q does not match key", alg)
+	}
+	return keyAlg, nil
+}
+
+var quoteReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// quote RFC 8941 문자열 직렬화
+func quote(s string) string {
+	return `"` + quoteReplacer.Replace(s) + `"`
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
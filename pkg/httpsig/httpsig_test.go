@@ -0,0 +1,270 @@
+package httpsig
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 9421 부록 B.1의 테스트 키
+var (
+	testSharedSecret = mustDecode("uzvJfB4u3N0Jy4T7NZ75MDVcr8zSTInedJtkgcu46YW4XByzNJjxBdtjUkdJPBtbmHhIDi6pcl8jsasjlTMtDQ==")
+	testEd25519Key   = ed25519.PublicKey(mustDecode("JrQLj5P/89iXES9+vFgrIy29clF9CC/oPPsw3c5D0bs="))
+)
+
+// rfcCreated RFC 9421 부록 B.2 서명의 created 값
+const rfcCreated = 1618884473
+
+func mustDecode(s string) []byte {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// rfcRequest RFC 9421 부록 B.2의 테스트 요청
+func rfcRequest(signatureInput, signature string) *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, "http://example.com/foo?param=Value&Pet=dog", strings.NewReader(`{"hello": "world"}`))
+	req.Header.Set("Date", "Tue, 20 Apr 2021 02:07:55 GMT")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Digest", "sha-512=:WZDPaVn/7XgHaAy8pmojAkGWoRx2UFChF41A2svX+TaPm+AbwAgBWnrIiYllu7BNNyealdVLvRwEmTHWXvJwew==:")
+	req.Header.Set("Content-Length", "18")
+	req.Header.Set(HeaderSignatureInput, signatureInput)
+	req.Header.Set(HeaderSignature, signature)
+	return req
+}
+
+func TestVerifyRFC9421Vectors(t *testing.T) {
+	keys := map[string]interface{}{
+		"test-shared-secret": testSharedSecret,
+		"test-key-ed25519":   testEd25519Key,
+	}
+	opts := VerifyOptions{
+		Key: func(keyID string) (interface{}, error) {
+			if key, ok := keys[keyID]; ok {
+				return key, nil
+			}
+			return nil, errors.New("unknown key")
+		},
+		MaxSkew: time.Minute,
+		Now:     func() time.Time { return time.Unix(rfcCreated, 0) },
+	}
+
+	const (
+		hmacInput    = `sig-b25=("date" "@authority" "content-type");created=1618884473;keyid="test-shared-secret"`
+		hmacSig      = `sig-b25=:pxcQw6G3AjtMBQjwo8XzkZf/bws5LelbaMk5rGIGtE8=:`
+		ed25519Input = `sig-b26=("date" "@method" "@path" "@authority" "content-type" "content-length");created=1618884473;keyid="test-key-ed25519"`
+		ed25519Sig   = `sig-b26=:wqcAqbmYJ2ji2glfAMaRy4gruYYnx2nEFN2HN6jrnDnQCK1u02Gb04v9EDgwUPiu4A0w6vuQv5lIp5WPpBKRCw==:`
+	)
+
+	tests := []struct {
+		name     string
+		req      func() *http.Request
+		required []string
+		now      time.Time
+		wantAlg  string
+		wantErr  bool
+	}{
+		{
+			name:     "B.2.5 hmac-sha256",
+			req:      func() *http.Request { return rfcRequest(hmacInput, hmacSig) },
+			required: []string{"date"},
+			wantAlg:  AlgHMACSHA256,
+		},
+		{
+			name:     "B.2.6 ed25519",
+			req:      func() *http.Request { return rfcRequest(ed25519Input, ed25519Sig) },
+			required: []string{"@method", "@path"},
+			wantAlg:  AlgEd25519,
+		},
+		{
+			name: "변조된 헤더",
+			req: func() *http.Request {
+				req := rfcRequest(hmacInput, hmacSig)
+				req.Header.Set("Content-Type", "text/plain")
+				return req
+			},
+			required: []string{"date"},
+			wantErr:  true,
+		},
+		{
+			name: "변조된 경로",
+			req: func() *http.Request {
+				req := rfcRequest(ed25519Input, ed25519Sig)
+				req.URL.Path = "/bar"
+				return req
+			},
+			required: []string{"@method", "@path"},
+			wantErr:  true,
+		},
+		{
+			name:     "필수 컴포넌트 누락",
+			req:      func() *http.Request { return rfcRequest(hmacInput, hmacSig) },
+			required: []string{"@method"},
+			wantErr:  true,
+		},
+		{
+			name:     "허용 오차 밖의 created",
+			req:      func() *http.Request { return rfcRequest(hmacInput, hmacSig) },
+			required: []string{"date"},
+			now:      time.Unix(rfcCreated, 0).Add(time.Hour),
+			wantErr:  true,
+		},
+		{
+			name: "알고리즘과 키 불일치",
+			req: func() *http.Request {
+				return rfcRequest(strings.Replace(hmacInput, `keyid=`, `alg="ed25519";keyid=`, 1), hmacSig)
+			},
+			required: []string{"date"},
+			wantErr:  true,
+		},
+		{
+			name: "알 수 없는 keyid",
+			req: func() *http.Request {
+				return rfcRequest(strings.Replace(hmacInput, "test-shared-secret", "unknown", 1), hmacSig)
+			},
+			required: []string{"date"},
+			wantErr:  true,
+		},
+		{
+			name:     "서명 없음",
+			req:      func() *http.Request { return rfcRequest(hmacInput, "") },
+			required: []string{"date"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := opts
+			o.Required = tt.required
+			if !tt.now.IsZero() {
+				o.Now = func() time.Time { return tt.now }
+			}
+			params, err := Verify(tt.req(), nil, o)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if params.Algorithm != tt.wantAlg {
+				t.Errorf("algorithm = %q, want %q", params.Algorithm, tt.wantAlg)
+			}
+			if params.Created.Unix() != rfcCreated {
+				t.Errorf("created = %d, want %d", params.Created.Unix(), rfcCreated)
+			}
+		})
+	}
+}
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := []byte(`{"user_id":"u1"}`)
+
+	tests := []struct {
+		name    string
+		signer  *Signer
+		key     interface{}
+		tamper  func(req *http.Request, body []byte) []byte
+		wantErr bool
+	}{
+		{
+			name:   "hmac-sha256",
+			signer: &Signer{KeyID: "svc", Algorithm: AlgHMACSHA256, Key: []byte("secret")},
+			key:    []byte("secret"),
+		},
+		{
+			name:   "ed25519",
+			signer: &Signer{KeyID: "svc", Algorithm: AlgEd25519, Key: priv},
+			key:    pub,
+		},
+		{
+			name:    "다른 시크릿",
+			signer:  &Signer{KeyID: "svc", Algorithm: AlgHMACSHA256, Key: []byte("secret")},
+			key:     []byte("other"),
+			wantErr: true,
+		},
+		{
+			name:   "변조된 본문",
+			signer: &Signer{KeyID: "svc", Algorithm: AlgHMACSHA256, Key: []byte("secret")},
+			key:    []byte("secret"),
+			tamper: func(_ *http.Request, _ []byte) []byte {
+				return []byte(`{"user_id":"admin"}`)
+			},
+			wantErr: true,
+		},
+		{
+			name:   "서명되지 않은 필수 헤더",
+			signer: &Signer{KeyID: "svc", Algorithm: AlgHMACSHA256, Key: []byte("secret")},
+			key:    []byte("secret"),
+			tamper: func(req *http.Request, body []byte) []byte {
+				req.Header.Set("Authorization", "Bearer injected")
+				return body
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, "http://qauth.internal/api/auth/token", nil)
+			if err := tt.signer.Sign(req, body); err != nil {
+				t.Fatal(err)
+			}
+			received := body
+			if tt.tamper != nil {
+				received = tt.tamper(req, body)
+			}
+			params, err := Verify(req, received, VerifyOptions{
+				Key:               func(string) (interface{}, error) { return tt.key, nil },
+				RequiredIfPresent: []string{"authorization"},
+				MaxSkew:           time.Minute,
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if params.KeyID != "svc" || params.Nonce == "" {
+				t.Errorf("params = %+v", params)
+			}
+		})
+	}
+}
+
+func TestVerifyUnsupportedAlgorithm(t *testing.T) {
+	if err := verify("rsa-pss-sha512", []byte("secret"), []byte("base"), []byte("sig")); err == nil {
+		t.Fatal("expected error for unsupported algorithm")
+	}
+}
+
+func TestComponentValueKeepsHeader(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+	req.Header.Add("X-Example", "  a ")
+	req.Header.Add("X-Example", "b  ")
+
+	got, err := componentValue(req, "x-example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "a, b" {
+		t.Errorf("componentValue = %q", got)
+	}
+	if values := req.Header.Values("X-Example"); values[0] != "  a " || values[1] != "b  " {
+		t.Errorf("요청 헤더가 변경됨: %q", values)
+	}
+}
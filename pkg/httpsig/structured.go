@@ -0,0 +1,225 @@
+package httpsig
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// signatureInput Signature-Input 헤더의 서명 하나
+type signatureInput struct {
+	components []string
+	params     map[string]string
+
+	// raw 서명 베이스의 @signature-params 줄에 그대로 사용할 원문
+	raw string
+}
+
+// param 문자열 또는 정수 파라미터
+func (s *signatureInput) param(name string) (string, bool) {
+	v, ok := s.params[name]
+	return v, ok
+}
+
+// intParam 정수 파라미터
+func (s *signatureInput) intParam(name string) (int64, bool) {
+	v, ok := s.params[name]
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	return n, err == nil
+}
+
+// parseSignatureInputs Signature-Input 딕셔너리 파싱 (RFC 8941 중 서명에 필요한 부분만 지원)
+func parseSignatureInputs(header string) (map[string]*signatureInput, error) {
+	p := &parser{s: header}
+	inputs := map[string]*signatureInput{}
+
+	for {
+		p.skipSpace()
+		if p.done() {
+			return inputs, nil
+		}
+
+		label, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume('=') {
+			return nil, errors.New("httpsig: malformed signature input")
+		}
+
+		start := p.i
+		components, err := p.innerList()
+		if err != nil {
+			return nil, err
+		}
+		params, err := p.params()
+		if err != nil {
+			return nil, err
+		}
+		inputs[label] = &signatureInput{
+			components: components,
+			params:     params,
+			raw:        header[start:p.i],
+		}
+
+		p.skipSpace()
+		if !p.done() && !p.consume(',') {
+			return nil, errors.New("httpsig: malformed signature input")
+		}
+	}
+}
+
+// parseSignatures Signature 딕셔너리 파싱 (레이블별 서명 바이트)
+func parseSignatures(header string) (map[string]string, error) {
+	p := &parser{s: header}
+	signatures := map[string]string{}
+
+	for {
+		p.skipSpace()
+		if p.done() {
+			return signatures, nil
+		}
+
+		label, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume('=') || !p.consume(':') {
+			return nil, errors.New("httpsig: malformed signature")
+		}
+		end := strings.IndexByte(p.s[p.i:], ':')
+		if end < 0 {
+			return nil, errors.New("httpsig: malformed signature")
+		}
+		signatures[label] = p.s[p.i : p.i+end]
+		p.i += end + 1
+
+		if _, err := p.params(); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if !p.done() && !p.consume(',') {
+			return nil, errors.New("httpsig: malformed signature")
+		}
+	}
+}
+
+type parser struct {
+	s string
+	i int
+}
+
+func (p *parser) done() bool {
+	return p.i >= len(p.s)
+}
+
+func (p *parser) skipSpace() {
+	for !p.done() && (p.s[p.i] == ' ' || p.s[p.i] == '\t') {
+		p.i++
+	}
+}
+
+func (p *parser) consume(c byte) bool {
+	if !p.done() && p.s[p.i] == c {
+		p.i++
+		return true
+	}
+	return false
+}
+
+// key 소문자, 숫자, "_-.*"로 이루어진 키
+func (p *parser) key() (string, error) {
+	start := p.i
+	for !p.done() {
+		c := p.s[p.i]
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '_' || c == '-' || c == '.' || c == '*' {
+			p.i++
+			continue
+		}
+		break
+	}
+	if start == p.i {
+		return "", errors.New("httpsig: missing key")
+	}
+	return p.s[start:p.i], nil
+}
+
+// str 따옴표로 감싼 문자열
+func (p *parser) str() (string, error) {
+	if !p.consume('"') {
+		return "", errors.New("httpsig: expected string")
+	}
+	var b strings.Builder
+	for !p.done() {
+		c := p.s[p.i]
+		p.i++
+		switch c {
+		case '\\':
+			if p.done() {
+				return "", errors.New("httpsig: unterminated string")
+			}
+			b.WriteByte(p.s[p.i])
+			p.i++
+		case '"':
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", errors.New("httpsig: unterminated string")
+}
+
+// innerList 문자열 항목의 inner list (컴포넌트 파라미터는 지원하지 않음)
+func (p *parser) innerList() ([]string, error) {
+	if !p.consume('(') {
+		return nil, errors.New("httpsig: expected inner list")
+	}
+	var items []string
+	for {
+		p.skipSpace()
+		if p.consume(')') {
+			return items, nil
+		}
+		item, err := p.str()
+		if err != nil {
+			return nil, err
+		}
+		if !p.done() && p.s[p.i] == ';' {
+			return nil, errors.New("httpsig: component parameters are not supported")
+		}
+		items = append(items, item)
+	}
+}
+
+// params ";key=value" 파라미터 (값은 문자열, 정수, 토큰, 불리언)
+func (p *parser) params() (map[string]string, error) {
+	params := map[string]string{}
+	for p.consume(';') {
+		p.skipSpace()
+		name, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume('=') {
+			params[name] = "?1"
+			continue
+		}
+		if !p.done() && p.s[p.i] == '"' {
+			v, err := p.str()
+			if err != nil {
+				return nil, err
+			}
+			params[name] = v
+			continue
+		}
+		start := p.i
+		for !p.done() && !strings.ContainsRune(";, )", rune(p.s[p.i])) {
+			p.i++
+		}
+		params[name] = p.s[start:p.i]
+	}
+	return params, nil
+}
@@ -1,75 +1,336 @@
 package jwt
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 )
 
+var (
+	// ErrInvalidToken 서명/형식/claims가 올바르지 않은 토큰
+	ErrInvalidToken = errors.New("token is invalid")
+
+	// ErrExpiredToken 만료된 토큰
+	ErrExpiredToken = errors.New("token is expired")
+
+	// ErrUnknownKey 검증 키를 찾을 수 없음
+	ErrUnknownKey = errors.New("unknown signing key")
+)
+
 type Service struct {
-	secretKey []byte
+	keys     atomic.Pointer[keySet]
+	rotateMu sync.Mutex
+	issuer   string
+	audience string
+}
+
+// keySet 서명/검증 키 묶음
+//
+// 교체할 때 묶음 전체를 바꿔 발급과 검증이 항상 같은 세트를 보도록 합니다.
+type keySet struct {
+	secretKey  []byte
+	signingKey *rsa.PrivateKey
+	keyID      string
+
+	// previous 직전 세트 (교체 전에 발급된 토큰 검증과 JWKS 배포에만 사용)
+	previous *keySet
+}
+
+// Option JWT 서비스 설정 옵션
+type Option func(*Service)
+
+// WithSigningKey RS256 서명 키 사용 (공개키는 JWKS로 배포)
+func WithSigningKey(key *rsa.PrivateKey, keyID string) Option {
+	return func(s *Service) {
+		keys := *s.keys.Load()
+		keys.signingKey = key
+		keys.keyID = keyID
+		s.keys.Store(&keys)
+	}
+}
+
+// WithIssuer 발급 토큰에 iss claim 추가
+func WithIssuer(issuer string) Option {
+	return func(s *Service) {
+		s.issuer = issuer
+	}
+}
+
+// WithAudience 발급 토큰에 aud claim 추가
+func WithAudience(audience string) Option {
+	return func(s *Service) {
+		s.audience = audience
+	}
 }
 
 // NewJWTService JWT 서비스 생성자
-func NewJWTService(secretKey string) *Service {
-	return &Service{
-		secretKey: []byte(secretKey),
+func NewJWTService(secretKey string, opts ...Option) *Service {
+	s := &Service{}
+	s.keys.Store(&keySet{secretKey: []byte(secretKey)})
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// TokenOption 발급 토큰 claims 추가 옵션
+type TokenOption func(jwt.MapClaims)
+
+// WithClaim 발급 토큰에 claim 추가 (user_id/exp/iat 등 기본 claim은 덮어쓰지 않음)
+func WithClaim(name string, value interface{}) TokenOption {
+	return func(claims jwt.MapClaims) {
+		if _, exists := claims[name]; !exists {
+			claims[name] = value
+		}
+	}
+}
+
+// WithLifetime 발급 토큰 유효 시간 지정 (기본 24시간 대신 사용)
+func WithLifetime(d time.Duration) TokenOption {
+	return func(claims jwt.MapClaims) {
+		claims["exp"] = time.Now().Add(d).Unix()
+	}
+}
+
+// WithTokenAudience 발급 토큰의 aud claim 지정 (서비스 기본 audience 대신 사용)
+func WithTokenAudience(audience string) TokenOption {
+	return func(claims jwt.MapClaims) {
+		claims["aud"] = audience
 	}
 }
 
 // GenerateToken JWT 토큰 생성
-func (s *Service) GenerateToken(userID string) (string, error) {
+func (s *Service) GenerateToken(userID string, opts ...TokenOption) (string, error) {
 	// 토큰 claims 설정
 	claims := jwt.MapClaims{
 		"user_id": userID,
 		"exp":     time.Now().Add(24 * time.Hour).Unix(),
 		"iat":     time.Now().Unix(),
 	}
+	if s.issuer != "" {
+		claims["iss"] = s.issuer
+	}
+	if s.audience != "" {
+		claims["aud"] = s.audience
+	}
+	for _, opt := range opts {
+		opt(claims)
+	}
+
+	keys := s.keys.Load()
+
+	// 서명 키가 설정되어 있으면 RS256, 아니면 HS256
+	if keys.signingKey != nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = keys.keyID
+		return token.SignedString(keys.signingKey)
+	}
 
 	// 토큰 생성
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	// 토큰 서명
-	return token.SignedString(s.secretKey)
+	return token.SignedString(keys.secretKey)
 }
 
 // ValidateToken JWT 토큰 검증
 func (s *Service) ValidateToken(tokenString string) (string, error) {
-	// 토큰 파싱 및 검증
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+	claims, err := s.ValidateClaims(tokenString)
+	if err != nil {
+		return "", err
+	}
+	return claims["user_id"].(string), nil
+}
+
+// ValidateClaims JWT 토큰 검증 후 전체 claims 반환 (user_id claim 필수)
+func (s *Service) ValidateClaims(tokenString string) (map[string]interface{}, error) {
+	// 토큰 파싱 및 서명 검증 (키 교체 직후에는 직전 세트로도 검증)
+	// RS256 서명 키가 설정되면 시크릿이 남아 있어도 HS256 토큰은 직전 세트까지 거부
+	keys := s.keys.Load()
+	symmetric := keys.signingKey == nil
+	claims, err := Parse(tokenString, keys.keyFunc(symmetric))
+	if err != nil && keys.previous != nil {
+		if previousClaims, previousErr := Parse(tokenString, keys.previous.keyFunc(symmetric)); previousErr == nil {
+			claims, err = previousClaims, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 만료 시간 검증 추가
+	if exp, ok := claims["exp"].(float64); ok {
+		if time.Now().Unix() > int64(exp) {
+			return nil, ErrExpiredToken
+		}
+	}
+	if nbf, ok := claims["nbf"].(float64); ok {
+		if time.Now().Unix() < int64(nbf) {
+			return nil, fmt.Errorf("%w: token is not valid yet", ErrInvalidToken)
+		}
+	}
+
+	// Claims에서 user_id 확인
+	if _, ok := claims["user_id"].(string); !ok {
+		return nil, fmt.Errorf("%w: missing user_id claim", ErrInvalidToken)
+	}
+
+	return claims, nil
+}
+
+// Audience 발급 토큰의 기본 aud (설정하지 않았으면 빈 문자열)
+func (s *Service) Audience() string {
+	return s.audience
+}
+
+// CheckKeys 서명 키로 토큰을 발급하고 다시 검증할 수 있는지 확인 (readiness 점검용)
+func (s *Service) CheckKeys() error {
+	keys := s.keys.Load()
+	if keys.signingKey == nil && len(keys.secretKey) == 0 {
+		return errors.New("no signing key configured")
+	}
+
+	token, err := s.GenerateToken("readiness-probe")
+	if err != nil {
+		return fmt.Errorf("sign probe token: %w", err)
+	}
+	if _, err := s.ValidateToken(token); err != nil {
+		return fmt.Errorf("verify probe token: %w", err)
+	}
+	return nil
+}
+
+// RotateKeys 서명 키 세트를 원자적으로 교체
+//
+// 직전 세트는 이미 발급된 토큰 검증과 JWKS 배포를 위해 한 세대 동안 유지됩니다.
+// RS256 키를 바꿀 때는 검증 측 캐시가 구분할 수 있도록 keyID도 함께 바꿔야 합니다.
+// RS256으로 전환하면 그 즉시 HS256 토큰은 직전 세트의 시크릿으로도 검증하지 않습니다.
+// 유출로 인한 교체라면 두 번 교체해 직전 세트까지 제거하세요.
+func (s *Service) RotateKeys(secretKey string, signingKey *rsa.PrivateKey, keyID string) {
+	s.rotateMu.Lock()
+	defer s.rotateMu.Unlock()
+
+	previous := *s.keys.Load()
+	previous.previous = nil
+
+	s.keys.Store(&keySet{
+		secretKey:  []byte(secretKey),
+		signingKey: signingKey,
+		keyID:      keyID,
+		previous:   &previous,
+	})
+}
+
+// JWKS 서명 공개키 목록 (HS256만 사용하는 경우 빈 목록)
+func (s *Service) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	for keys := s.keys.Load(); keys != nil; keys = keys.previous {
+		if keys.signingKey == nil {
+			continue
+		}
+		if _, exists := jwks.Key(keys.keyID); exists {
+			continue
+		}
+
+		key, err := NewJWK(&keys.signingKey.PublicKey, keys.keyID)
+		if err != nil {
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, key)
+	}
+	return jwks
+}
+
+// keyFunc 토큰 헤더에 맞는 검증 키 조회 함수 (symmetric이 false면 HS256 시크릿은 사용하지 않음)
+func (ks *keySet) keyFunc(symmetric bool) KeyFunc {
+	return func(kid, alg string) (interface{}, error) {
+		if ks.signingKey != nil && kid == ks.keyID {
+			return &ks.signingKey.PublicKey, nil
+		}
+		if symmetric && len(ks.secretKey) > 0 && alg == jwt.SigningMethodHS256.Alg() {
+			return ks.secretKey, nil
+		}
+		return nil, ErrUnknownKey
+	}
+}
+
+// KeyFunc 토큰 헤더의 kid/alg로 검증 키 조회
+type KeyFunc func(kid, alg string) (interface{}, error)
+
+// Parse 서명만 검증하고 claims 반환
+//
+// exp/nbf/iat 등 시간 관련 검증은 leeway 정책이 호출자마다 다르므로 수행하지 않습니다.
+// 키 종류와 alg가 일치하지 않으면 거부해 알고리즘 혼동 공격을 막습니다.
+func Parse(tokenString string, keyFunc KeyFunc) (map[string]interface{}, error) {
+	parser := &jwt.Parser{SkipClaimsValidation: true}
+
+	token, err := parser.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := keyFunc(kid, token.Method.Alg())
+		if err != nil {
+			return nil, err
+		}
+
 		// 알고리즘 검증 추가
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		switch key.(type) {
+		case []byte:
+			_, ok := token.Method.(*jwt.SigningMethodHMAC)
+			if !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+		case *rsa.PublicKey:
+			_, ok := token.Method.(*jwt.SigningMethodRSA)
+			if !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+		case *ecdsa.PublicKey:
+			_, ok := token.Method.(*jwt.SigningMethodECDSA)
+			if !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+		case ed25519.PublicKey:
+			_, ok := token.Method.(*jwt.SigningMethodEd25519)
+			if !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+		default:
+			return nil, jwt.ErrInvalidKeyType
 		}
-		return s.secretKey, nil
+		return key, nil
 	})
 
 	if err != nil {
-		return "", fmt.Errorf("token parsing error: %w", err)
+		return nil, fmt.Errorf("%w: token parsing error: %w", ErrInvalidToken, err)
 	}
 
 	if !token.Valid {
-		return "", jwt.ErrSignatureInvalid
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, jwt.ErrSignatureInvalid)
 	}
 
-	// Claims에서 user_id 추출
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
-		return "", jwt.ErrInvalidKeyType
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, jwt.ErrInvalidKeyType)
 	}
 
-	// 만료 시간 검증 추가
-	if exp, ok := claims["exp"].(float64); ok {
-		if time.Now().Unix() > int64(exp) {
-			return "", fmt.Errorf("token is expired")
-		}
-	}
+	return claims, nil
+}
 
-	userID, ok := claims["user_id"].(string)
-	if !ok {
-		return "", jwt.ErrInvalidKeyType
+// DecodeToken 서명 검증 없이 JWT 헤더와 claims 디코딩 (디버깅 용도)
+func DecodeToken(tokenString string) (map[string]interface{}, map[string]interface{}, error) {
+	parser := &jwt.Parser{}
+	claims := jwt.MapClaims{}
+
+	token, _, err := parser.ParseUnverified(tokenString, claims)
+	if err != nil {
+		return nil, nil, fmt.Errorf("token parsing error: %w", err)
 	}
 
-	return userID, nil
+	return token.Header, claims, nil
 }
@@ -0,0 +1,6 @@
+// Package proto qauth gRPC API 정의
+//
+// 코드 재생성: protoc, protoc-gen-go(v1.34.2), protoc-gen-go-grpc(v1.5.1) 설치 후 go generate ./pkg/proto
+package proto
+
+//go:generate protoc -I . --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative qauth/v1/auth.proto
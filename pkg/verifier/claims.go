@@ -0,0 +1,133 @@
+package verifier
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"strings"
+	"time"
+)
+
+// claimImpersonation 관리자 대리 로그인 토큰 표시 claim
+const claimImpersonation = "impersonation"
+
+// Claims 검증된 토큰의 claims
+type Claims struct {
+	UserID    string
+	Subject   string
+	Issuer    string
+	Audience  []string
+	ExpiresAt time.Time
+	NotBefore time.Time
+	IssuedAt  time.Time
+
+	// CertificateThumbprint 인증서 바인딩 토큰의 cnf.x5t#S256 (바인딩되지 않았으면 빈 값)
+	CertificateThumbprint string
+
+	// Scopes 교환 토큰의 scope claim (공백 구분 값을 분리, 없으면 nil)
+	Scopes []string
+
+	// Actor 위임 토큰이면 사용자를 대신해 호출한 주체 (act.sub, 위임 토큰이 아니면 빈 값)
+	Actor string
+
+	// Impersonation 관리자 대리 로그인 토큰 여부 (Actor 체인의 처음 주체가 관리자)
+	Impersonation bool
+
+	// Raw 전체 claims 원본
+	Raw map[string]interface{}
+}
+
+// HasAudience aud claim에 대상 포함 여부
+func (c *Claims) HasAudience(audience string) bool {
+	for _, aud := range c.Audience {
+		if aud == audience {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScope scope claim에 권한 포함 여부
+func (c *Claims) HasScope(scope string) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// BoundTo 인증서 바인딩 토큰이면 cert가 바인딩된 인증서인지 확인 (바인딩되지 않은 토큰은 항상 true)
+func (c *Claims) BoundTo(cert *x509.Certificate) bool {
+	if c.CertificateThumbprint == "" {
+		return true
+	}
+	if cert == nil {
+		return false
+	}
+	sum := sha256.Sum256(cert.Raw)
+	return subtle.ConstantTimeCompare([]byte(base64.RawURLEncoding.EncodeToString(sum[:])), []byte(c.CertificateThumbprint)) == 1
+}
+
+// newClaims claims 맵을 Claims로 변환
+func newClaims(raw map[string]interface{}) *Claims {
+	c := &Claims{
+		Raw:       raw,
+		ExpiresAt: numericDate(raw["exp"]),
+		NotBefore: numericDate(raw["nbf"]),
+		IssuedAt:  numericDate(raw["iat"]),
+	}
+	c.UserID, _ = raw["user_id"].(string)
+	c.Subject, _ = raw["sub"].(string)
+	c.Issuer, _ = raw["iss"].(string)
+	if cnf, ok := raw["cnf"].(map[string]interface{}); ok {
+		c.CertificateThumbprint, _ = cnf["x5t#S256"].(string)
+	}
+	if scope, ok := raw["scope"].(string); ok {
+		c.Scopes = strings.Fields(scope)
+	}
+	if act, ok := raw["act"].(map[string]interface{}); ok {
+		c.Actor, _ = act["sub"].(string)
+	}
+	c.Impersonation = raw[claimImpersonation] == true
+
+	switch aud := raw["aud"].(type) {
+	case string:
+		c.Audience = []string{aud}
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok {
+				c.Audience = append(c.Audience, s)
+			}
+		}
+	}
+
+	return c
+}
+
+// numericDate JWT NumericDate를 time.Time으로 변환 (없으면 zero)
+func numericDate(v interface{}) time.Time {
+	switch n := v.(type) {
+	case float64:
+		return time.Unix(int64(n), 0)
+	case int64:
+		return time.Unix(n, 0)
+	default:
+		return time.Time{}
+	}
+}
+
+type contextKey struct{}
+
+// ContextWithClaims context에 claims 추가
+func ContextWithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, contextKey{}, claims)
+}
+
+// ClaimsFromContext 미들웨어가 추가한 claims 조회
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(contextKey{}).(*Claims)
+	return claims, ok
+}
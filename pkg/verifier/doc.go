@@ -0,0 +1,14 @@
+// Package verifier 리소스 서버용 qauth 토큰 오프라인 검증 라이브러리
+//
+// qauth의 JWKS를 조회/캐시해 매 요청마다 qauth를 호출하지 않고 서명과
+// exp/nbf/iss/aud를 검증합니다. 필요하면 RevocationChecker로 폐기 여부를 추가 확인합니다.
+//
+//	v, err := verifier.New(verifier.Config{
+//		JWKSURL:  "http://qauth:8080/.well-known/jwks.json",
+//		Audience: "orders",
+//	})
+//	mux.Handle("/orders", v.Middleware(ordersHandler))
+//
+//	// 핸들러에서
+//	claims, _ := verifier.ClaimsFromContext(r.Context())
+package verifier
@@ -0,0 +1,134 @@
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/signalable/qauth/pkg/jwt"
+)
+
+// keySet 주기적으로 갱신되는 JWKS 캐시
+type keySet struct {
+	url             string
+	client          *http.Client
+	refreshInterval time.Duration
+	minRefreshDelay time.Duration
+
+	mu        sync.RWMutex
+	keys      map[string]interface{}
+	fetchedAt time.Time
+
+	// fetchMu 동시 갱신 요청을 하나로 합침
+	fetchMu sync.Mutex
+}
+
+func newKeySet(url string, client *http.Client, refreshInterval, minRefreshDelay time.Duration) *keySet {
+	return &keySet{
+		url:             url,
+		client:          client,
+		refreshInterval: refreshInterval,
+		minRefreshDelay: minRefreshDelay,
+	}
+}
+
+// lookup kid로 공개키 조회 (캐시가 오래되었거나 모르는 kid면 갱신)
+func (s *keySet) lookup(ctx context.Context, kid string) (interface{}, error) {
+	key, fresh, ok := s.cached(kid)
+	if ok && fresh {
+		return key, nil
+	}
+
+	if err := s.refresh(ctx, ok); err != nil {
+		// 갱신 실패 시 기존 키가 있으면 계속 사용
+		if ok {
+			return key, nil
+		}
+		return nil, err
+	}
+
+	key, _, ok = s.cached(kid)
+	if !ok {
+		return nil, fmt.Errorf("%w: kid %q", jwt.ErrUnknownKey, kid)
+	}
+	return key, nil
+}
+
+// cached 캐시된 키와 캐시 신선도 반환
+func (s *keySet) cached(kid string) (interface{}, bool, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.keys[kid]
+	fresh := time.Since(s.fetchedAt) < s.refreshInterval
+	return key, fresh, ok
+}
+
+// refresh JWKS 재조회 (알 수 없는 kid로 인한 갱신은 minRefreshDelay로 제한)
+func (s *keySet) refresh(ctx context.Context, known bool) error {
+	s.fetchMu.Lock()
+	defer s.fetchMu.Unlock()
+
+	s.mu.RLock()
+	since := time.Since(s.fetchedAt)
+	s.mu.RUnlock()
+
+	if known && since < s.refreshInterval {
+		return nil
+	}
+	if !known && since < s.minRefreshDelay {
+		return nil
+	}
+
+	keys, err := s.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.fetchedAt = time.Now()
+	s.mu.Unlock()
+	return nil
+}
+
+// fetch JWKS 조회 및 공개키 파싱
+func (s *keySet) fetch(ctx context.Context) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("verifier: failed to build JWKS request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("verifier: failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("verifier: unexpected JWKS status %d", resp.StatusCode)
+	}
+
+	var set jwt.JWKS
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&set); err != nil {
+		return nil, fmt.Errorf("verifier: failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.PublicKey()
+		if err != nil {
+			// 지원하지 않는 키는 건너뜀
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+	return keys, nil
+}
@@ -0,0 +1,66 @@
+package verifier
+
+import (
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Middleware Bearer 토큰을 검증하고 claims를 context에 추가하는 net/http 미들웨어
+//
+// 인증서 바인딩 토큰은 서버가 직접 TLS를 종료하고 클라이언트 인증서를 요구해야 통과합니다.
+func (v *Verifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		claims, err := v.Verify(r.Context(), token)
+		if err != nil {
+			if !isTokenError(err) {
+				http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		// 인증서 바인딩 토큰은 이 연결의 클라이언트 인증서가 바인딩된 인증서여야 함 (RFC 8705 3)
+		if !claims.BoundTo(peerCertificate(r)) {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(ContextWithClaims(r.Context(), claims)))
+	})
+}
+
+// isTokenError 토큰 자체의 문제인지 (인프라 장애가 아닌지) 판단
+func isTokenError(err error) bool {
+	return errors.Is(err, ErrInvalidToken) ||
+		errors.Is(err, ErrExpiredToken) ||
+		errors.Is(err, ErrRevokedToken)
+}
+
+// bearerToken Authorization 헤더에서 Bearer 토큰 추출
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// peerCertificate 요청 연결의 클라이언트 인증서 (없으면 nil)
+func peerCertificate(r *http.Request) *x509.Certificate {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return nil
+	}
+	return r.TLS.PeerCertificates[0]
+}
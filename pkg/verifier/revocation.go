@@ -0,0 +1,40 @@
+package verifier
+
+import (
+	"context"
+	"errors"
+
+	"github.com/signalable/qauth/pkg/client"
+)
+
+// RevocationChecker 토큰 폐기 여부 확인
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, token string, claims *Claims) (bool, error)
+}
+
+// RevocationCheckerFunc 함수형 RevocationChecker
+type RevocationCheckerFunc func(ctx context.Context, token string, claims *Claims) (bool, error)
+
+// IsRevoked RevocationChecker 구현
+func (f RevocationCheckerFunc) IsRevoked(ctx context.Context, token string, claims *Claims) (bool, error) {
+	return f(ctx, token, claims)
+}
+
+// ClientRevocationChecker qauth 토큰 상태 API로 폐기 여부 확인
+//
+// 상태 API는 키 바인딩을 확인하지 않으므로 인증서 바인딩 토큰도 폐기 여부만 확인하며,
+// 바인딩은 Middleware가 이 연결의 클라이언트 인증서로 확인합니다.
+func ClientRevocationChecker(c *client.Client) RevocationChecker {
+	return RevocationCheckerFunc(func(ctx context.Context, token string, _ *Claims) (bool, error) {
+		resp, err := c.TokenStatus(ctx, token)
+		if err != nil {
+			if errors.Is(err, client.ErrInvalidToken) ||
+				errors.Is(err, client.ErrExpiredToken) ||
+				errors.Is(err, client.ErrRevokedToken) {
+				return true, nil
+			}
+			return false, err
+		}
+		return !resp.Active, nil
+	})
+}
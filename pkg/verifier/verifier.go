@@ -0,0 +1,138 @@
+package verifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/signalable/qauth/pkg/jwt"
+)
+
+var (
+	// ErrInvalidToken 서명/형식/claims가 올바르지 않거나 오프라인으로 검증할 수 없는 토큰
+	ErrInvalidToken = errors.New("verifier: invalid token")
+
+	// ErrExpiredToken 만료된 토큰
+	ErrExpiredToken = errors.New("verifier: token is expired")
+
+	// ErrRevokedToken RevocationChecker가 폐기되었다고 판단한 토큰
+	ErrRevokedToken = errors.New("verifier: token is revoked")
+)
+
+const (
+	defaultLeeway          = 30 * time.Second
+	defaultRefreshInterval = 5 * time.Minute
+	defaultMinRefreshDelay = 10 * time.Second
+)
+
+// Config 오프라인 검증 설정
+type Config struct {
+	// JWKSURL qauth 공개키 목록 주소 (예: http://qauth:8080/.well-known/jwks.json)
+	JWKSURL string
+
+	// Issuer 설정 시 iss claim 일치 필수
+	Issuer string
+
+	// Audience 설정 시 aud claim에 포함 필수
+	Audience string
+
+	// Leeway exp/nbf/iat 검증 시 허용할 시계 오차
+	Leeway time.Duration
+
+	// RefreshInterval JWKS 주기적 갱신 간격
+	RefreshInterval time.Duration
+
+	// HTTPClient JWKS 조회에 사용할 클라이언트
+	HTTPClient *http.Client
+
+	// RevocationChecker 설정 시 서명 검증 후 폐기 여부 확인
+	RevocationChecker RevocationChecker
+}
+
+// Verifier JWKS 기반 JWT 오프라인 검증기
+type Verifier struct {
+	cfg  Config
+	keys *keySet
+}
+
+// New 검증기 생성자
+func New(cfg Config) (*Verifier, error) {
+	if cfg.JWKSURL == "" {
+		return nil, errors.New("verifier: JWKSURL is required")
+	}
+	if cfg.Leeway == 0 {
+		cfg.Leeway = defaultLeeway
+	}
+	if cfg.RefreshInterval == 0 {
+		cfg.RefreshInterval = defaultRefreshInterval
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 5 * time.Second}
+	}
+
+	return &Verifier{
+		cfg:  cfg,
+		keys: newKeySet(cfg.JWKSURL, cfg.HTTPClient, cfg.RefreshInterval, defaultMinRefreshDelay),
+	}, nil
+}
+
+// Verify 토큰 서명과 claims 검증
+func (v *Verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	raw, err := jwt.Parse(token, func(kid, alg string) (interface{}, error) {
+		return v.keys.lookup(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	claims := newClaims(raw)
+	if err := v.validate(claims, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// DPoP 바인딩 토큰은 증명 jti 재사용 여부를 qauth만 확인할 수 있으므로 오프라인 검증 불가
+	if cnf, ok := raw["cnf"].(map[string]interface{}); ok && cnf["jkt"] != nil {
+		return nil, fmt.Errorf("%w: DPoP-bound token requires online validation", ErrInvalidToken)
+	}
+
+	if v.cfg.RevocationChecker != nil {
+		revoked, err := v.cfg.RevocationChecker.IsRevoked(ctx, token, claims)
+		if err != nil {
+			return nil, fmt.Errorf("verifier: revocation check failed: %w", err)
+		}
+		if revoked {
+			return nil, ErrRevokedToken
+		}
+	}
+
+	return claims, nil
+}
+
+// validate 시간 및 iss/aud claims 검증
+func (v *Verifier) validate(c *Claims, now time.Time) error {
+	leeway := v.cfg.Leeway
+
+	if c.ExpiresAt.IsZero() {
+		return fmt.Errorf("%w: missing exp claim", ErrInvalidToken)
+	}
+	if now.After(c.ExpiresAt.Add(leeway)) {
+		return ErrExpiredToken
+	}
+	if !c.NotBefore.IsZero() && now.Add(leeway).Before(c.NotBefore) {
+		return fmt.Errorf("%w: token is not valid yet", ErrInvalidToken)
+	}
+	if !c.IssuedAt.IsZero() && now.Add(leeway).Before(c.IssuedAt) {
+		return fmt.Errorf("%w: token issued in the future", ErrInvalidToken)
+	}
+
+	if v.cfg.Issuer != "" && c.Issuer != v.cfg.Issuer {
+		return fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, c.Issuer)
+	}
+	if v.cfg.Audience != "" && !c.HasAudience(v.cfg.Audience) {
+		return fmt.Errorf("%w: token not intended for %q", ErrInvalidToken, v.cfg.Audience)
+	}
+
+	return nil
+}